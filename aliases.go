@@ -0,0 +1,105 @@
+package purl
+
+import (
+	"fmt"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// ParseOption configures ParseWithOptions and Registry.Parse.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	canonicalTypes bool
+	warn           func(TypeWarning)
+}
+
+// CanonicalTypes makes parsing rewrite aliased types, such as pkg:rubygems or
+// pkg:go, to their canonical type (pkg:gem, pkg:golang) so the same package
+// always gets the same identity. If warn is non-nil it is called once for a
+// PURL whose type was rewritten or is deprecated.
+func CanonicalTypes(warn func(TypeWarning)) ParseOption {
+	return func(o *parseOptions) {
+		o.canonicalTypes = true
+		o.warn = warn
+	}
+}
+
+// TypeWarning reports a non-canonical or deprecated type seen while parsing
+// with CanonicalTypes.
+type TypeWarning struct {
+	Type       string // type as written in the input
+	Canonical  string // type the PURL was rewritten to, if Type is an alias
+	ReplacedBy string // preferred type, if Type is deprecated
+	Message    string
+}
+
+func (w TypeWarning) String() string {
+	return w.Message
+}
+
+// CanonicalType resolves a type name against the default registry.
+// See Registry.CanonicalType.
+func CanonicalType(purlType string) (string, bool) {
+	return defaultRegistry().CanonicalType(purlType)
+}
+
+// CanonicalType returns the canonical name for a type or one of its
+// aliases, and reports whether the name is known to the registry.
+// Unknown names are returned unchanged.
+func (r *Registry) CanonicalType(purlType string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.cat.types[purlType]; ok {
+		return purlType, true
+	}
+	for name, e := range r.cat.types {
+		for _, alias := range e.cfg.Aliases {
+			if alias == purlType {
+				return name, true
+			}
+		}
+	}
+	return purlType, false
+}
+
+// canonicalize rewrites an aliased type to its canonical name, reparsing so
+// the canonical type's normalization and rules apply, and reports aliased
+// and deprecated types to warn.
+func (r *Registry) canonicalize(p packageurl.PackageURL, warn func(TypeWarning)) (*PURL, error) {
+	var w *TypeWarning
+
+	if canonical, _ := r.CanonicalType(p.Type); canonical != p.Type {
+		w = &TypeWarning{
+			Type:      p.Type,
+			Canonical: canonical,
+			Message:   fmt.Sprintf("purl type %q is an alias of %q", p.Type, canonical),
+		}
+		p.Type = canonical
+		s := p.ToString()
+		var err error
+		if p, err = packageurl.FromString(s); err != nil {
+			return nil, r.newParseError(s, err)
+		}
+	}
+
+	if cfg, ok := r.lookupType(p.Type); ok && cfg.Deprecated != nil {
+		if w == nil {
+			w = &TypeWarning{Type: p.Type}
+		}
+		w.ReplacedBy = cfg.Deprecated.ReplacedBy
+		w.Message = fmt.Sprintf("purl type %q is deprecated", p.Type)
+		if cfg.Deprecated.ReplacedBy != "" {
+			w.Message += fmt.Sprintf(" in favor of %q", cfg.Deprecated.ReplacedBy)
+		}
+		if cfg.Deprecated.Message != "" {
+			w.Message += ": " + cfg.Deprecated.Message
+		}
+	}
+
+	if w != nil && warn != nil {
+		warn(*w)
+	}
+	return &PURL{p}, nil
+}
@@ -0,0 +1,107 @@
+package purl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCanonicalTypes(t *testing.T) {
+	tests := []struct {
+		input         string
+		want          string
+		wantWarning   bool
+		wantCanonical string
+	}{
+		{"pkg:rubygems/rails@7.0.4", "pkg:gem/rails@7.0.4", true, "gem"},
+		{"pkg:packagist/Laravel/Framework@10.0.0", "pkg:composer/laravel/framework@10.0.0", true, "composer"},
+		{"pkg:go/github.com/gorilla/mux@v1.8.0", "pkg:golang/github.com/gorilla/mux@v1.8.0", true, "golang"},
+		{"pkg:github-actions/actions/checkout@v4", "pkg:githubactions/actions/checkout@v4", true, "githubactions"},
+		{"pkg:gem/rails@7.0.4", "pkg:gem/rails@7.0.4", false, ""},
+		{"pkg:docker/nginx@1.21.6", "pkg:docker/nginx@1.21.6", true, ""},
+		{"pkg:unknowntype/foo@1.0", "pkg:unknowntype/foo@1.0", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var warnings []TypeWarning
+			p, err := ParseWithOptions(tt.input, CanonicalTypes(func(w TypeWarning) {
+				warnings = append(warnings, w)
+			}))
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if got := p.String(); got != tt.want {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+			if got := len(warnings) > 0; got != tt.wantWarning {
+				t.Fatalf("warnings = %v, want warning %v", warnings, tt.wantWarning)
+			}
+			if tt.wantWarning && warnings[0].Canonical != tt.wantCanonical {
+				t.Errorf("Canonical = %q, want %q", warnings[0].Canonical, tt.wantCanonical)
+			}
+		})
+	}
+}
+
+func TestParseWithoutCanonicalTypes(t *testing.T) {
+	p, err := Parse("pkg:rubygems/rails@7.0.4")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if p.Type != "rubygems" {
+		t.Errorf("Type = %q, want aliases left alone without the option", p.Type)
+	}
+
+	// A nil callback still rewrites
+	p, err = ParseWithOptions("pkg:rubygems/rails@7.0.4", CanonicalTypes(nil))
+	if err != nil || p.Type != "gem" {
+		t.Errorf("ParseWithOptions(CanonicalTypes(nil)) = %v, %v", p, err)
+	}
+}
+
+func TestDeprecatedTypeWarning(t *testing.T) {
+	var got TypeWarning
+	if _, err := ParseWithOptions("pkg:docker/nginx@1.21.6", CanonicalTypes(func(w TypeWarning) { got = w })); err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if got.Type != "docker" || got.ReplacedBy != "oci" || got.Canonical != "" {
+		t.Errorf("warning = %+v", got)
+	}
+	if !strings.Contains(got.String(), "deprecated") {
+		t.Errorf("String() = %q, want deprecation message", got.String())
+	}
+}
+
+func TestCanonicalType(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"gem", "gem", true},
+		{"rubygems", "gem", true},
+		{"go", "golang", true},
+		{"github-actions", "githubactions", true},
+		{"nope", "nope", false},
+	}
+	for _, tt := range tests {
+		got, ok := CanonicalType(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CanonicalType(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRegistryAliases(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterType("acme", TypeConfig{Description: "Acme", Aliases: []string{"acme-legacy"}}); err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+	p, err := r.Parse("pkg:acme-legacy/widget@1.0", CanonicalTypes(nil))
+	if err != nil || p.String() != "pkg:acme/widget@1.0" {
+		t.Errorf("Registry.Parse() = %v, %v", p, err)
+	}
+	if err := r.RegisterType("bad", TypeConfig{Aliases: []string{"Bad Alias"}}); err == nil {
+		t.Error("RegisterType() with invalid alias error = nil")
+	}
+}
@@ -0,0 +1,422 @@
+package purl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// ErrInvalidTypes is returned when a types document fails validation.
+var ErrInvalidTypes = errors.New("invalid types document")
+
+// FieldSource identifies the catalog layer that supplied a TypeConfig field.
+type FieldSource string
+
+// Catalog layers, from lowest to highest precedence in the order they are
+// usually applied.
+const (
+	SourceEmbedded   FieldSource = "embedded"   // the embedded types.json
+	SourceLoaded     FieldSource = "loaded"     // LoadTypes
+	SourceMerged     FieldSource = "merged"     // MergeTypes
+	SourceSpec       FieldSource = "spec"       // LoadTypeDefinitions
+	SourceRegistered FieldSource = "registered" // RegisterType
+)
+
+// catalogEntry holds one type's configuration along with the JSON object it
+// was decoded from, which field-level merges operate on, and the layer each
+// leaf field came from.
+type catalogEntry struct {
+	raw     map[string]any
+	sources map[string]FieldSource
+	cfg     TypeConfig
+}
+
+type catalog struct {
+	version string
+	types   map[string]*catalogEntry
+}
+
+var (
+	embeddedOnce sync.Once
+	embedded     catalog
+	catalogErr   error
+)
+
+// embeddedCatalog returns the catalog parsed from the embedded types.json,
+// and the error if parsing failed, in which case the catalog holds whatever
+// loaded before the failure. It is shared and must be cloned before
+// modification.
+func embeddedCatalog() (*catalog, error) {
+	embeddedOnce.Do(func() {
+		embedded.types = make(map[string]*catalogEntry)
+		doc, err := decodeTypes(bytes.NewReader(typesJSON), false)
+		if err != nil {
+			catalogErr = err
+			return
+		}
+		embedded.version = doc.Version
+		for name, raw := range doc.Types {
+			if err := embedded.replace(name, raw, SourceEmbedded); err != nil {
+				catalogErr = err
+				return
+			}
+		}
+		catalogErr = embedded.checkAliases()
+	})
+	return &embedded, catalogErr
+}
+
+// typesDocument is the raw form of a types.json document. Types are kept as
+// JSON objects so MergeTypes can tell which fields a document sets.
+type typesDocument struct {
+	Version     string                    `json:"version"`
+	Description string                    `json:"description"`
+	Source      string                    `json:"source"`
+	LastUpdated string                    `json:"last_updated"`
+	Types       map[string]map[string]any `json:"types"`
+}
+
+// decodeTypes reads a types document and checks it against the typesData
+// schema. With partial set, type entries need not be complete on their own.
+func decodeTypes(r io.Reader, partial bool) (*typesDocument, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var typed typesData
+	if err := dec.Decode(&typed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTypes, err)
+	}
+	if typed.Types == nil {
+		return nil, fmt.Errorf("%w: missing \"types\" object", ErrInvalidTypes)
+	}
+
+	var doc typesDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTypes, err)
+	}
+	if !partial {
+		for name := range typed.Types {
+			cfg := typed.Types[name]
+			if err := validateTypeConfig(name, &cfg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &doc, nil
+}
+
+// validateTypeConfig checks the parts of a TypeConfig that JSON decoding
+// cannot: the type name, namespace_requirement values, reverse_regex,
+// aliases and qualifier definitions.
+func validateTypeConfig(name string, cfg *TypeConfig) error {
+	if !packageurl.TypePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidType, name)
+	}
+	switch cfg.NamespaceRequirement {
+	case "", "required", "optional", "prohibited":
+	default:
+		return fmt.Errorf("%w: type %q: namespace_requirement %q", ErrInvalidTypes, name, cfg.NamespaceRequirement)
+	}
+	if cfg.RegistryConfig != nil && cfg.RegistryConfig.ReverseRegex != "" {
+		if _, err := regexp.Compile(cfg.RegistryConfig.ReverseRegex); err != nil {
+			return fmt.Errorf("%w: type %q: reverse_regex: %v", ErrInvalidTypes, name, err)
+		}
+	}
+	for _, alias := range cfg.Aliases {
+		if !packageurl.TypePattern.MatchString(alias) || alias != strings.ToLower(alias) {
+			return fmt.Errorf("%w: type %q: alias %q", ErrInvalidTypes, name, alias)
+		}
+	}
+	for _, q := range cfg.Qualifiers {
+		if err := ValidateQualifierKey(q.Key); err != nil {
+			return fmt.Errorf("%w: type %q: %v", ErrInvalidTypes, name, err)
+		}
+		if _, err := regexp.Compile(q.Pattern); err != nil {
+			return fmt.Errorf("%w: type %q: qualifier %q pattern: %v", ErrInvalidTypes, name, q.Key, err)
+		}
+	}
+	return nil
+}
+
+// LoadTypes reads a types.json document and layers it over the registry at
+// type granularity: each type in the document replaces any existing
+// definition wholesale, and types it does not mention are kept. The
+// document's version, if set, becomes TypesVersion. Nothing is changed if
+// the document is invalid.
+func (r *Registry) LoadTypes(rd io.Reader) error {
+	doc, err := decodeTypes(rd, false)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := r.cat.clone()
+	for name, raw := range doc.Types {
+		if err := next.replace(name, raw, SourceLoaded); err != nil {
+			return err
+		}
+	}
+	if doc.Version != "" {
+		next.version = doc.Version
+	}
+	if err := next.checkAliases(); err != nil {
+		return err
+	}
+	r.setCatalog(next)
+	return nil
+}
+
+// MergeTypes reads a types.json document and merges it into the registry
+// field by field: only the fields the document sets are overridden, nested
+// objects such as registry_config are merged recursively, and arrays such
+// as examples replace the existing value. Types not already in the registry
+// are added. Nothing is changed if the document or the merged result is
+// invalid.
+func (r *Registry) MergeTypes(rd io.Reader) error {
+	doc, err := decodeTypes(rd, true)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := r.cat.clone()
+	for name, raw := range doc.Types {
+		if err := next.merge(name, raw, SourceMerged); err != nil {
+			return err
+		}
+	}
+	if err := next.checkAliases(); err != nil {
+		return err
+	}
+	r.setCatalog(next)
+	return nil
+}
+
+// TypeFieldSources reports which catalog layer supplied each field of a
+// type's configuration, keyed by JSON field path such as "default_registry"
+// or "registry_config.components.namespace". It returns nil for unknown types.
+func (r *Registry) TypeFieldSources(purlType string) map[string]FieldSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.cat.types[purlType]
+	if !ok {
+		return nil
+	}
+	sources := make(map[string]FieldSource, len(e.sources))
+	for k, v := range e.sources {
+		sources[k] = v
+	}
+	return sources
+}
+
+// LoadTypes calls LoadTypes on the default registry.
+func LoadTypes(rd io.Reader) error {
+	return defaultRegistry().LoadTypes(rd)
+}
+
+// MergeTypes calls MergeTypes on the default registry.
+func MergeTypes(rd io.Reader) error {
+	return defaultRegistry().MergeTypes(rd)
+}
+
+// TypeFieldSources calls TypeFieldSources on the default registry.
+func TypeFieldSources(purlType string) map[string]FieldSource {
+	return defaultRegistry().TypeFieldSources(purlType)
+}
+
+// setCatalog replaces the registry's catalog and rebuilds the indexes
+// derived from it. The caller must hold r.mu for writing.
+func (r *Registry) setCatalog(c *catalog) {
+	r.cat = *c
+	r.rebuildIndexes()
+}
+
+// rebuildIndexes recomputes the URL parsing indexes from the catalog. The
+// caller must hold r.mu for writing.
+func (r *Registry) rebuildIndexes() {
+	r.hosts = newHostIndex(&r.cat, r.compileRegex)
+	r.downloads = newDownloadIndex(&r.cat, r.compileRegex)
+}
+
+// clone returns a deep copy of the catalog so a failed update can be discarded.
+func (c *catalog) clone() *catalog {
+	next := &catalog{version: c.version, types: make(map[string]*catalogEntry, len(c.types))}
+	for name, e := range c.types {
+		sources := make(map[string]FieldSource, len(e.sources))
+		for k, v := range e.sources {
+			sources[k] = v
+		}
+		next.types[name] = &catalogEntry{raw: copyRaw(e.raw), sources: sources, cfg: e.cfg}
+	}
+	return next
+}
+
+// checkAliases reports an alias that names a type or is claimed by more
+// than one type, either of which would make CanonicalType ambiguous.
+func (c *catalog) checkAliases() error {
+	names := make([]string, 0, len(c.types))
+	for name := range c.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owner := make(map[string]string)
+	for _, name := range names {
+		for _, alias := range c.types[name].cfg.Aliases {
+			switch {
+			case c.types[alias] != nil:
+				return fmt.Errorf("%w: type %q: alias %q is also a type", ErrInvalidTypes, name, alias)
+			case owner[alias] != "":
+				return fmt.Errorf("%w: type %q: alias %q is also an alias of %q", ErrInvalidTypes, name, alias, owner[alias])
+			}
+			owner[alias] = name
+		}
+	}
+	return nil
+}
+
+// replace sets a type's definition to raw, attributing every field to source.
+func (c *catalog) replace(name string, raw map[string]any, source FieldSource) error {
+	cfg, err := fromRaw(name, raw)
+	if err != nil {
+		return err
+	}
+	c.types[name] = newCatalogEntry(copyRaw(raw), cfg, source)
+	return nil
+}
+
+// merge layers raw over a type's existing definition field by field.
+func (c *catalog) merge(name string, raw map[string]any, source FieldSource) error {
+	e, ok := c.types[name]
+	if !ok {
+		return c.replace(name, raw, source)
+	}
+	mergeRaw(e.raw, raw, "", e.sources, source)
+	cfg, err := fromRaw(name, e.raw)
+	if err != nil {
+		return err
+	}
+	e.cfg = cfg
+	return nil
+}
+
+// setFields replaces whole top-level fields of a type's definition, keeping
+// the fields it does not mention, and adds the type if it is new.
+func (c *catalog) setFields(name string, fields map[string]any, source FieldSource) error {
+	e, ok := c.types[name]
+	if !ok {
+		return c.replace(name, fields, source)
+	}
+	for k := range fields {
+		delete(e.raw, k)
+	}
+	mergeRaw(e.raw, fields, "", e.sources, source)
+	cfg, err := fromRaw(name, e.raw)
+	if err != nil {
+		return err
+	}
+	e.cfg = cfg
+	return nil
+}
+
+func newCatalogEntry(raw map[string]any, cfg TypeConfig, source FieldSource) *catalogEntry {
+	sources := make(map[string]FieldSource)
+	setSources(raw, "", sources, source)
+	return &catalogEntry{raw: raw, sources: sources, cfg: cfg}
+}
+
+// fromRaw decodes and validates a type's JSON object.
+func fromRaw(name string, raw map[string]any) (TypeConfig, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return TypeConfig{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg TypeConfig
+	if err := dec.Decode(&cfg); err != nil {
+		return TypeConfig{}, fmt.Errorf("%w: type %q: %v", ErrInvalidTypes, name, err)
+	}
+	if err := validateTypeConfig(name, &cfg); err != nil {
+		return TypeConfig{}, err
+	}
+	return cfg, nil
+}
+
+// toRaw encodes a value, such as a TypeConfig, as a JSON object.
+func toRaw(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	err = json.Unmarshal(data, &raw)
+	return raw, err
+}
+
+// mergeRaw copies src into dst, recursing into objects present in both, and
+// records source for every leaf it writes.
+func mergeRaw(dst, src map[string]any, prefix string, sources map[string]FieldSource, source FieldSource) {
+	for k, v := range src {
+		path := prefix + k
+		srcObj, srcIsObj := v.(map[string]any)
+		dstObj, dstIsObj := dst[k].(map[string]any)
+		if srcIsObj && dstIsObj {
+			mergeRaw(dstObj, srcObj, path+".", sources, source)
+			continue
+		}
+		for existing := range sources {
+			if existing == path || strings.HasPrefix(existing, path+".") {
+				delete(sources, existing)
+			}
+		}
+		if srcIsObj {
+			dst[k] = copyRaw(srcObj)
+			setSources(srcObj, path+".", sources, source)
+			continue
+		}
+		dst[k] = v
+		sources[path] = source
+	}
+}
+
+// setSources attributes every leaf field of raw to source.
+func setSources(raw map[string]any, prefix string, sources map[string]FieldSource, source FieldSource) {
+	for k, v := range raw {
+		if obj, ok := v.(map[string]any); ok {
+			setSources(obj, prefix+k+".", sources, source)
+			continue
+		}
+		sources[prefix+k] = source
+	}
+}
+
+// copyRaw deep-copies the nested objects of a JSON object. Other values are
+// never mutated in place, so they are shared.
+func copyRaw(raw map[string]any) map[string]any {
+	out := make(map[string]any, len(raw))
+	for k, v := range raw {
+		if obj, ok := v.(map[string]any); ok {
+			v = copyRaw(obj)
+		}
+		out[k] = v
+	}
+	return out
+}
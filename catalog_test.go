@@ -0,0 +1,146 @@
+package purl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadTypes(t *testing.T) {
+	restoreCatalog(t)
+
+	doc := `{
+		"version": "2099.1",
+		"types": {
+			"npm": {
+				"description": "replaced npm",
+				"default_registry": "https://npm.internal.example",
+				"namespace_requirement": "optional"
+			},
+			"acme": {
+				"description": "Acme packages",
+				"default_registry": null,
+				"namespace_requirement": "prohibited"
+			}
+		}
+	}`
+	if err := LoadTypes(strings.NewReader(doc)); err != nil {
+		t.Fatalf("LoadTypes() error: %v", err)
+	}
+
+	if got := TypesVersion(); got != "2099.1" {
+		t.Errorf("TypesVersion() = %q, want %q", got, "2099.1")
+	}
+	npm := TypeInfo("npm")
+	if npm == nil || npm.Description != "replaced npm" {
+		t.Fatalf("TypeInfo(npm) = %v, want replaced config", npm)
+	}
+	if npm.RegistryConfig != nil {
+		t.Error("LoadTypes kept registry_config from the embedded npm entry")
+	}
+	if !IsKnownType("acme") {
+		t.Error("IsKnownType(acme) = false after LoadTypes")
+	}
+	if !IsKnownType("pypi") {
+		t.Error("LoadTypes dropped a type the document did not mention")
+	}
+	if got := TypeFieldSources("npm")["description"]; got != SourceLoaded {
+		t.Errorf("npm description source = %q, want %q", got, SourceLoaded)
+	}
+}
+
+func TestMergeTypes(t *testing.T) {
+	restoreCatalog(t)
+
+	before := *TypeInfo("npm")
+	doc := `{"types": {"npm": {"registry_config": {"base_url": "https://npm.internal.example"}}}}`
+	if err := MergeTypes(strings.NewReader(doc)); err != nil {
+		t.Fatalf("MergeTypes() error: %v", err)
+	}
+
+	npm := TypeInfo("npm")
+	if got := npm.RegistryConfig.BaseURL; got != "https://npm.internal.example" {
+		t.Errorf("BaseURL = %q, want merged value", got)
+	}
+	if npm.RegistryConfig.URITemplate != before.RegistryConfig.URITemplate {
+		t.Errorf("URITemplate = %q, want embedded %q", npm.RegistryConfig.URITemplate, before.RegistryConfig.URITemplate)
+	}
+	if npm.Description != before.Description {
+		t.Errorf("Description = %q, want embedded %q", npm.Description, before.Description)
+	}
+
+	sources := TypeFieldSources("npm")
+	tests := map[string]FieldSource{
+		"registry_config.base_url":             SourceMerged,
+		"registry_config.uri_template":         SourceEmbedded,
+		"registry_config.components.namespace": SourceEmbedded,
+		"description":                          SourceEmbedded,
+	}
+	for field, want := range tests {
+		if got := sources[field]; got != want {
+			t.Errorf("source of %s = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestMergeTypesReplacesArrays(t *testing.T) {
+	restoreCatalog(t)
+
+	doc := `{"types": {"npm": {"examples": ["pkg:npm/left-pad@1.3.0"]}}}`
+	if err := MergeTypes(strings.NewReader(doc)); err != nil {
+		t.Fatalf("MergeTypes() error: %v", err)
+	}
+	got := TypeInfo("npm").Examples
+	if len(got) != 1 || got[0] != "pkg:npm/left-pad@1.3.0" {
+		t.Errorf("Examples = %v, want replaced list", got)
+	}
+}
+
+func TestLoadTypesInvalid(t *testing.T) {
+	restoreCatalog(t)
+
+	tests := []struct {
+		name string
+		doc  string
+		load func(string) error
+	}{
+		{"invalid json", `{"types": `, loadString},
+		{"missing types", `{"version": "1"}`, loadString},
+		{"unknown field", `{"types": {"npm": {"descripton": "typo"}}}`, loadString},
+		{"bad regex", `{"types": {"npm": {"registry_config": {"reverse_regex": "("}}}}`, loadString},
+		{"bad namespace requirement", `{"types": {"npm": {"namespace_requirement": "sometimes"}}}`, loadString},
+		{"bad type name", `{"types": {"1npm": {}}}`, loadString},
+		{"merge unknown field", `{"types": {"npm": {"registry_config": {"base": "x"}}}}`, mergeString},
+		{"merge bad qualifier pattern", `{"types": {"npm": {"qualifiers": [{"key": "tag", "pattern": "("}]}}}`, mergeString},
+		{"merge bad qualifier key", `{"types": {"npm": {"qualifiers": [{"key": "1tag"}]}}}`, mergeString},
+		{"merge bad regex", `{"types": {"npm": {"registry_config": {"reverse_regex": "["}}}}`, mergeString},
+		{"alias names a type", `{"types": {"npm": {"aliases": ["pypi"]}}}`, mergeString},
+		{"alias of another type", `{"types": {"npm": {"aliases": ["go"]}}}`, mergeString},
+		{"load alias of another type", `{"types": {"acme": {"aliases": ["rubygems"]}}}`, loadString},
+	}
+
+	want := TypeInfo("npm").Description
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.load(tt.doc)
+			if err == nil {
+				t.Fatal("error = nil, want error")
+			}
+			if !errors.Is(err, ErrInvalidTypes) && !errors.Is(err, ErrInvalidType) {
+				t.Errorf("error = %v, want ErrInvalidTypes or ErrInvalidType", err)
+			}
+			if got := TypeInfo("npm"); got == nil || got.Description != want {
+				t.Error("failed load modified the catalog")
+			}
+		})
+	}
+}
+
+func TestTypeFieldSourcesUnknown(t *testing.T) {
+	if got := TypeFieldSources("not-a-type"); got != nil {
+		t.Errorf("TypeFieldSources() = %v, want nil", got)
+	}
+}
+
+func loadString(s string) error  { return LoadTypes(strings.NewReader(s)) }
+func mergeString(s string) error { return MergeTypes(strings.NewReader(s)) }
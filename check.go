@@ -0,0 +1,201 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// ErrCatalogIntegrity is returned by Check for inconsistencies in the type
+// catalog.
+var ErrCatalogIntegrity = errors.New("type catalog integrity problem")
+
+// CatalogProblem describes one inconsistency found by Check.
+// It unwraps to ErrCatalogIntegrity.
+type CatalogProblem struct {
+	Type    string // type whose configuration has the problem
+	Field   string // JSON path of the field, e.g. "registry_config.uri_template"
+	Problem string
+}
+
+func (e *CatalogProblem) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Type, e.Field, e.Problem)
+}
+
+func (e *CatalogProblem) Unwrap() error {
+	return ErrCatalogIntegrity
+}
+
+// templatePlaceholder matches {placeholder} references in URI templates.
+var templatePlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// templatePlaceholders are the placeholders fillTemplate substitutes.
+var templatePlaceholders = map[string]bool{
+	"namespace":      true,
+	"namespace_path": true,
+	"name":           true,
+	"version":        true,
+	"name_lower":     true,
+	"version_lower":  true,
+}
+
+// CatalogError returns the error, if any, from loading the embedded
+// types.json. When it is non-nil the default registry is empty, so TypeInfo
+// returns nil and IsKnownType returns false for every type.
+func CatalogError() error {
+	return defaultRegistry().CatalogError()
+}
+
+// CatalogError returns the error, if any, from loading the embedded
+// types.json into a registry created with NewRegistry.
+func (r *Registry) CatalogError() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.loadErr
+}
+
+// Check reports integrity problems in the default registry.
+// See Registry.Check.
+func Check() error {
+	return defaultRegistry().Check()
+}
+
+// Check inspects every type in the registry for problems that individual
+// loads can't catch: reverse_regex patterns that don't compile or capture
+// too few groups, URI templates with unknown placeholders, download regexes
+// that can't yield a name and version, registry components that contradict
+// namespace_requirement, aliases that collide with other types, deprecations
+// pointing at unknown types, and examples that don't parse as their own
+// type. Problems are returned together, joined with errors.Join, each as a
+// *CatalogProblem. A registry whose embedded types.json failed to load
+// reports that error too.
+func (r *Registry) Check() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+	if r.loadErr != nil {
+		errs = append(errs, r.loadErr)
+	}
+
+	names := make([]string, 0, len(r.cat.types))
+	for name := range r.cat.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliasOwner := make(map[string]string)
+	for _, name := range names {
+		cfg := &r.cat.types[name].cfg
+		report := func(field, format string, args ...any) {
+			errs = append(errs, &CatalogProblem{Type: name, Field: field, Problem: fmt.Sprintf(format, args...)})
+		}
+
+		if rc := cfg.RegistryConfig; rc != nil {
+			checkRegistryConfig(cfg, rc, report)
+		}
+
+		for _, alias := range cfg.Aliases {
+			switch {
+			case r.cat.types[alias] != nil:
+				report("aliases", "alias %q is also a type", alias)
+			case aliasOwner[alias] != "":
+				report("aliases", "alias %q is also an alias of %q", alias, aliasOwner[alias])
+			default:
+				aliasOwner[alias] = name
+			}
+		}
+
+		if d := cfg.Deprecated; d != nil && d.ReplacedBy != "" && r.cat.types[d.ReplacedBy] == nil {
+			report("deprecated.replaced_by", "unknown type %q", d.ReplacedBy)
+		}
+
+		for _, ex := range cfg.Examples {
+			if _, err := checkExample(name, ex); err != nil {
+				report("examples", "%v", err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkRegistryConfig reports problems with a type's registry_config.
+func checkRegistryConfig(cfg *TypeConfig, rc *RegistryConfig, report func(field, format string, args ...any)) {
+	comp := rc.Components
+
+	if rc.ReverseRegex != "" {
+		re, err := regexp.Compile(rc.ReverseRegex)
+		switch {
+		case err != nil:
+			report("registry_config.reverse_regex", "%v", err)
+		default:
+			want := 1
+			if comp.Namespace {
+				want = 2 //nolint:mnd
+			}
+			if re.NumSubexp() < want {
+				report("registry_config.reverse_regex", "captures %d groups, components need at least %d", re.NumSubexp(), want)
+			}
+		}
+	}
+
+	if rc.DownloadRegex != "" {
+		checkDownloadRegex(rc, report)
+	}
+
+	templates := []struct {
+		field, value string
+		download     bool
+	}{
+		{"uri_template", rc.URITemplate, false},
+		{"uri_template_no_namespace", rc.URITemplateNoNamespace, false},
+		{"uri_template_with_version", rc.URITemplateWithVersion, false},
+		{"uri_template_with_version_no_namespace", rc.URITemplateWithVersionNoNS, false},
+		{"api_template", rc.APITemplate, false},
+		{"api_template_no_namespace", rc.APITemplateNoNamespace, false},
+		{"download_template", rc.DownloadTemplate, true},
+		{"download_template_no_namespace", rc.DownloadTemplateNoNS, true},
+	}
+	for _, t := range templates {
+		for _, m := range templatePlaceholder.FindAllStringSubmatch(t.value, -1) {
+			if t.download && qualifierPlaceholderKey(m[1]) != "" {
+				continue
+			}
+			if !templatePlaceholders[m[1]] {
+				report("registry_config."+t.field, "unknown placeholder %q", m[0])
+			}
+		}
+	}
+
+	switch {
+	case comp.NamespaceRequired && !comp.Namespace:
+		report("registry_config.components.namespace_required", "set but components.namespace is false")
+	case comp.NamespaceRequired && cfg.NamespaceRequirement != "" && !cfg.NamespaceRequired():
+		report("registry_config.components.namespace_required", "set but namespace_requirement is %q", cfg.NamespaceRequirement)
+	case comp.Namespace && cfg.NamespaceProhibited():
+		report("registry_config.components.namespace", "set but namespace_requirement is %q", cfg.NamespaceRequirement)
+	}
+}
+
+// checkDownloadRegex reports a download_regex that does not compile or
+// cannot yield a name and version.
+func checkDownloadRegex(rc *RegistryConfig, report func(field, format string, args ...any)) {
+	re, err := regexp.Compile(rc.DownloadRegex)
+	if err != nil {
+		report("registry_config.download_regex", "%v", err)
+		return
+	}
+	if re.SubexpIndex("file") >= 0 {
+		if rc.DownloadTemplate == "" {
+			report("registry_config.download_regex", "has a file group but no download_template")
+		}
+		return
+	}
+	for _, group := range []string{"name", "version"} {
+		if re.SubexpIndex(group) < 0 {
+			report("registry_config.download_regex", "has no %s group", group)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package purl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckEmbedded(t *testing.T) {
+	if err := CatalogError(); err != nil {
+		t.Fatalf("CatalogError() = %v", err)
+	}
+	if err := Check(); err != nil {
+		t.Errorf("Check() = %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       TypeConfig
+		wantField string
+	}{
+		{
+			name: "unknown placeholder",
+			cfg: TypeConfig{RegistryConfig: &RegistryConfig{
+				URITemplate: "https://acme.example/{nmae}",
+			}},
+			wantField: "registry_config.uri_template",
+		},
+		{
+			name: "qualifier placeholder outside download template",
+			cfg: TypeConfig{RegistryConfig: &RegistryConfig{
+				APITemplate:      "https://acme.example/{name}?arch={qualifier.arch}",
+				DownloadTemplate: "https://acme.example/{name}-{version}{-qualifier.arch}.tar.gz",
+			}},
+			wantField: "registry_config.api_template",
+		},
+		{
+			name: "download regex without version",
+			cfg: TypeConfig{RegistryConfig: &RegistryConfig{
+				DownloadRegex: `^https://acme\.example/(?P<name>[^/]+)\.tar\.gz$`,
+			}},
+			wantField: "registry_config.download_regex",
+		},
+		{
+			name: "too few capture groups",
+			cfg: TypeConfig{RegistryConfig: &RegistryConfig{
+				ReverseRegex: `^https://acme\.example/(.+)`,
+				Components:   RegistryComponents{Namespace: true},
+			}},
+			wantField: "registry_config.reverse_regex",
+		},
+		{
+			name: "namespace required contradicts requirement",
+			cfg: TypeConfig{NamespaceRequirement: "optional", RegistryConfig: &RegistryConfig{
+				Components: RegistryComponents{Namespace: true, NamespaceRequired: true},
+			}},
+			wantField: "registry_config.components.namespace_required",
+		},
+		{
+			name: "namespace used but prohibited",
+			cfg: TypeConfig{NamespaceRequirement: "prohibited", RegistryConfig: &RegistryConfig{
+				Components: RegistryComponents{Namespace: true},
+			}},
+			wantField: "registry_config.components.namespace",
+		},
+		{
+			name:      "example of wrong type",
+			cfg:       TypeConfig{Examples: []string{"pkg:npm/lodash"}},
+			wantField: "examples",
+		},
+		{
+			name:      "unknown replacement",
+			cfg:       TypeConfig{Deprecated: &Deprecation{ReplacedBy: "nothing"}},
+			wantField: "deprecated.replaced_by",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			if err := r.RegisterType("acme", tt.cfg); err != nil {
+				t.Fatalf("RegisterType() error: %v", err)
+			}
+			err := r.Check()
+			if !errors.Is(err, ErrCatalogIntegrity) {
+				t.Fatalf("Check() = %v, want ErrCatalogIntegrity", err)
+			}
+			var problem *CatalogProblem
+			if !errors.As(err, &problem) || problem.Type != "acme" || problem.Field != tt.wantField {
+				t.Errorf("Check() problem = %+v, want acme %s", problem, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestCheckAliasShadowsType(t *testing.T) {
+	// The loaders reject alias collisions, so plant one in the catalog
+	// directly to exercise Check's own report.
+	r := NewRegistry()
+	if err := r.RegisterType("acme", TypeConfig{}); err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+	r.cat.types["acme"].cfg.Aliases = []string{"npm"}
+
+	err := r.Check()
+	var problem *CatalogProblem
+	if !errors.As(err, &problem) || problem.Type != "acme" || problem.Field != "aliases" {
+		t.Errorf("Check() = %v, want acme aliases problem", err)
+	}
+}
+
+func TestCheckReportsAll(t *testing.T) {
+	r := NewRegistry()
+	doc := `{"types": {
+		"npm": {"registry_config": {"uri_template": "https://x.example/{pkg}"}},
+		"cargo": {"examples": ["pkg:npm/lodash"]}
+	}}`
+	if err := r.MergeTypes(strings.NewReader(doc)); err != nil {
+		t.Fatalf("MergeTypes() error: %v", err)
+	}
+	err := r.Check()
+	if err == nil {
+		t.Fatal("Check() = nil")
+	}
+	for _, want := range []string{"npm: registry_config.uri_template", "cargo: examples"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Check() = %v, missing %q", err, want)
+		}
+	}
+}
+
+func TestCatalogErrorZeroRegistry(t *testing.T) {
+	var r Registry
+	if err := r.CatalogError(); err != nil {
+		t.Errorf("CatalogError() = %v, want nil", err)
+	}
+	if err := r.Check(); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+}
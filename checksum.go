@@ -0,0 +1,195 @@
+package purl
+
+import (
+	"crypto/md5"  //nolint:gosec // md5 checksums appear in real-world PURLs
+	"crypto/sha1" //nolint:gosec // sha1 checksums appear in real-world PURLs
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNoChecksum is returned when a PURL has no checksum qualifier to verify.
+var ErrNoChecksum = errors.New("purl has no checksum qualifier")
+
+// ErrUnsupportedChecksum is returned for checksum algorithms this package cannot compute.
+var ErrUnsupportedChecksum = errors.New("unsupported checksum algorithm")
+
+// ErrChecksumMismatch is returned when a computed digest differs from the checksum qualifier.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrMalformedChecksum is returned for checksum qualifier entries that are not algorithm:value pairs.
+var ErrMalformedChecksum = errors.New("malformed checksum")
+
+// ChecksumEntryError reports one checksum qualifier entry or algorithm that
+// could not be used. It unwraps to ErrUnsupportedChecksum or
+// ErrMalformedChecksum.
+type ChecksumEntryError struct {
+	Entry string // the entry as written, or the algorithm name
+	Err   error
+}
+
+func (e *ChecksumEntryError) Error() string {
+	return fmt.Sprintf("%v: %q", e.Err, e.Entry)
+}
+
+func (e *ChecksumEntryError) Unwrap() error {
+	return e.Err
+}
+
+// ChecksumMismatchError reports one algorithm whose digest did not match.
+// It unwraps to ErrChecksumMismatch.
+type ChecksumMismatchError struct {
+	Algorithm string
+	Want      string
+	Got       string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch: want %s, got %s", e.Algorithm, e.Want, e.Got)
+}
+
+func (e *ChecksumMismatchError) Unwrap() error {
+	return ErrChecksumMismatch
+}
+
+// checksumAlgorithms maps checksum qualifier algorithm names to hash constructors.
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// VerifyChecksum reads r to the end, hashing it with every supported
+// algorithm listed in the checksum qualifier, and reports any digest that
+// does not match. Mismatches are returned as *ChecksumMismatchError values
+// joined with errors.Join, so errors.Is(err, ErrChecksumMismatch) holds when
+// any differ. Malformed entries are reported alongside them as
+// *ChecksumEntryError. Entries with unsupported algorithms are ignored unless
+// no entry is supported, in which case each is reported and nothing is read.
+func (p *PURL) VerifyChecksum(r io.Reader) error {
+	raw := p.Qualifier(QualifierChecksum)
+	if raw == "" {
+		return ErrNoChecksum
+	}
+
+	entries, errs := parseChecksums(raw)
+	var want []Checksum
+	var unsupported []error
+	for _, c := range entries {
+		if _, ok := checksumAlgorithms[c.Algorithm]; ok {
+			want = append(want, c)
+		} else {
+			unsupported = append(unsupported, &ChecksumEntryError{Entry: c.String(), Err: ErrUnsupportedChecksum})
+		}
+	}
+	if len(want) == 0 {
+		return errors.Join(append(errs, unsupported...)...)
+	}
+
+	algorithms := make([]string, len(want))
+	for i, c := range want {
+		algorithms[i] = c.Algorithm
+	}
+	got, err := ComputeChecksums(r, algorithms...)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range want {
+		if !strings.EqualFold(c.Value, got[i].Value) {
+			errs = append(errs, &ChecksumMismatchError{Algorithm: c.Algorithm, Want: c.Value, Got: got[i].Value})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseChecksums splits a checksum qualifier value into its entries,
+// returning an ErrMalformedChecksum *ChecksumEntryError for each entry
+// without an algorithm or value.
+func parseChecksums(raw string) ([]Checksum, []error) {
+	var checksums []Checksum
+	var errs []error
+	for _, entry := range strings.Split(raw, ",") {
+		algo, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || algo == "" || value == "" {
+			errs = append(errs, &ChecksumEntryError{Entry: entry, Err: ErrMalformedChecksum})
+			continue
+		}
+		checksums = append(checksums, Checksum{Algorithm: strings.ToLower(algo), Value: value})
+	}
+	return checksums, errs
+}
+
+// ComputeChecksums reads r to the end and returns its digest for each
+// algorithm, in the order given. It defaults to sha256 when no algorithms
+// are given. Supported algorithms are md5, sha1, sha256, sha384 and sha512.
+//
+// Unsupported algorithms are left out of the result and reported together,
+// each as a *ChecksumEntryError wrapping ErrUnsupportedChecksum; the digests
+// for the supported ones are still returned. When none is supported, r is
+// not read.
+func ComputeChecksums(r io.Reader, algorithms ...string) ([]Checksum, error) {
+	if len(algorithms) == 0 {
+		algorithms = []string{"sha256"}
+	}
+
+	var names []string
+	var hashes []hash.Hash
+	var writers []io.Writer
+	var errs []error
+	for _, algo := range algorithms {
+		name := strings.ToLower(algo)
+		newHash, ok := checksumAlgorithms[name]
+		if !ok {
+			errs = append(errs, &ChecksumEntryError{Entry: algo, Err: ErrUnsupportedChecksum})
+			continue
+		}
+		h := newHash()
+		names = append(names, name)
+		hashes = append(hashes, h)
+		writers = append(writers, h)
+	}
+	if len(hashes) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+
+	checksums := make([]Checksum, len(hashes))
+	for i, h := range hashes {
+		checksums[i] = Checksum{Algorithm: names[i], Value: hex.EncodeToString(h.Sum(nil))}
+	}
+	return checksums, errors.Join(errs...)
+}
+
+// WithFileChecksums hashes the file at path and returns a copy of the PURL
+// with the checksum qualifier set to the result. It defaults to sha256 when
+// no algorithms are given.
+func (p *PURL) WithFileChecksums(path string, algorithms ...string) (*PURL, error) {
+	f, err := os.Open(path) //nolint:gosec // path is chosen by the caller
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	checksums, err := ComputeChecksums(f, algorithms...)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(checksums))
+	for i, c := range checksums {
+		values[i] = c.String()
+	}
+	return p.WithQualifier(QualifierChecksum, strings.Join(values, ",")), nil
+}
@@ -0,0 +1,135 @@
+package purl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloSHA1   = "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	tests := []struct {
+		name    string
+		purl    string
+		wantErr error
+	}{
+		{"sha256 match", "pkg:generic/hello@1?checksum=sha256:" + helloSHA256, nil},
+		{"multiple match", "pkg:generic/hello@1?checksum=sha1:" + helloSHA1 + ",sha256:" + strings.ToUpper(helloSHA256), nil},
+		{"mismatch", "pkg:generic/hello@1?checksum=sha1:" + helloSHA1 + ",sha256:deadbeef", ErrChecksumMismatch},
+		{"unsupported", "pkg:generic/hello@1?checksum=crc32:1234", ErrUnsupportedChecksum},
+		{"unsupported and match", "pkg:generic/hello@1?checksum=sha3-256:1234,sha256:" + helloSHA256, nil},
+		{"unsupported and mismatch", "pkg:generic/hello@1?checksum=sha3-256:1234,sha256:deadbeef", ErrChecksumMismatch},
+		{"malformed and match", "pkg:generic/hello@1?checksum=sha256:" + helloSHA256 + ",sha1", ErrMalformedChecksum},
+		{"only malformed", "pkg:generic/hello@1?checksum=sha256:", ErrMalformedChecksum},
+		{"missing", "pkg:generic/hello@1", ErrNoChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			err = p.VerifyChecksum(strings.NewReader("hello"))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("VerifyChecksum() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyChecksum() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksumMismatchDetail(t *testing.T) {
+	p, _ := Parse("pkg:generic/hello@1?checksum=sha256:deadbeef")
+	err := p.VerifyChecksum(strings.NewReader("hello"))
+
+	var mismatch *ChecksumMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("VerifyChecksum() error = %v, want *ChecksumMismatchError", err)
+	}
+	if mismatch.Algorithm != "sha256" || mismatch.Want != "deadbeef" || mismatch.Got != helloSHA256 {
+		t.Errorf("mismatch = %+v", mismatch)
+	}
+}
+
+func TestVerifyChecksumEntryErrors(t *testing.T) {
+	p, _ := Parse("pkg:generic/hello@1?checksum=crc32:1234,sha1,sha3-256:abcd")
+	err := p.VerifyChecksum(strings.NewReader("hello"))
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("VerifyChecksum() = %v, want joined errors", err)
+	}
+
+	var entries []string
+	for _, e := range joined.Unwrap() {
+		var entryErr *ChecksumEntryError
+		if !errors.As(e, &entryErr) {
+			t.Fatalf("VerifyChecksum() error %v is %T, want *ChecksumEntryError", e, e)
+		}
+		entries = append(entries, entryErr.Entry)
+	}
+	want := []string{"sha1", "crc32:1234", "sha3-256:abcd"}
+	if strings.Join(entries, " ") != strings.Join(want, " ") {
+		t.Errorf("VerifyChecksum() entries = %q, want %q", entries, want)
+	}
+	if !errors.Is(err, ErrMalformedChecksum) || !errors.Is(err, ErrUnsupportedChecksum) {
+		t.Errorf("VerifyChecksum() = %v, want malformed and unsupported", err)
+	}
+}
+
+func TestComputeChecksumsUnsupported(t *testing.T) {
+	got, err := ComputeChecksums(strings.NewReader("hello"), "crc32", "SHA256")
+	if !errors.Is(err, ErrUnsupportedChecksum) {
+		t.Errorf("ComputeChecksums() error = %v, want ErrUnsupportedChecksum", err)
+	}
+	if len(got) != 1 || got[0] != (Checksum{Algorithm: "sha256", Value: helloSHA256}) {
+		t.Errorf("ComputeChecksums() = %v, want the sha256 digest", got)
+	}
+
+	got, err = ComputeChecksums(strings.NewReader("hello"), "crc32")
+	if got != nil || !errors.Is(err, ErrUnsupportedChecksum) {
+		t.Errorf("ComputeChecksums(crc32) = %v, %v", got, err)
+	}
+}
+
+func TestWithFileChecksums(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, _ := Parse("pkg:generic/hello@1")
+	p2, err := p.WithFileChecksums(path, "sha1", "sha256")
+	if err != nil {
+		t.Fatalf("WithFileChecksums() error: %v", err)
+	}
+	if got, want := p2.Qualifier("checksum"), "sha1:"+helloSHA1+",sha256:"+helloSHA256; got != want {
+		t.Errorf("checksum = %q, want %q", got, want)
+	}
+	if err := p2.VerifyChecksum(strings.NewReader("hello")); err != nil {
+		t.Errorf("VerifyChecksum() after WithFileChecksums = %v", err)
+	}
+
+	p3, err := p.WithFileChecksums(path)
+	if err != nil {
+		t.Fatalf("WithFileChecksums() error: %v", err)
+	}
+	if got, want := p3.Qualifier("checksum"), "sha256:"+helloSHA256; got != want {
+		t.Errorf("default checksum = %q, want %q", got, want)
+	}
+
+	if _, err := p.WithFileChecksums(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("WithFileChecksums(missing) error = nil, want error")
+	}
+}
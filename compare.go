@@ -0,0 +1,119 @@
+package purl
+
+import (
+	"strings"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// EqualOption configures which components Equal and Compare consider.
+type EqualOption func(*equalOptions)
+
+type equalOptions struct {
+	ignoreVersion    bool
+	ignoreQualifiers bool
+	ignoreSubpath    bool
+}
+
+// IgnoreVersion makes Equal and Compare skip the version.
+func IgnoreVersion() EqualOption {
+	return func(o *equalOptions) { o.ignoreVersion = true }
+}
+
+// IgnoreQualifiers makes Equal and Compare skip all qualifiers.
+func IgnoreQualifiers() EqualOption {
+	return func(o *equalOptions) { o.ignoreQualifiers = true }
+}
+
+// IgnoreSubpath makes Equal and Compare skip the subpath.
+func IgnoreSubpath() EqualOption {
+	return func(o *equalOptions) { o.ignoreSubpath = true }
+}
+
+// CanonicalKey returns a normalized string form of the PURL that is safe to
+// use as a map or database key. It applies the same per-type normalization as
+// Parse and New, sorts qualifiers, drops empty qualifier values, and removes
+// a repository_url qualifier that points at the type's default registry, so
+// pkg:npm/lodash?repository_url=https://registry.npmjs.org and pkg:npm/lodash
+// share a key. A nil PURL has the empty key.
+func (p *PURL) CanonicalKey() string {
+	if p == nil {
+		return ""
+	}
+	c := p.canonical(equalOptions{})
+	return c.String()
+}
+
+// Equal reports whether p and other identify the same package after
+// canonicalization (see CanonicalKey). Options can exclude the version,
+// qualifiers or subpath from the comparison.
+func (p *PURL) Equal(other *PURL, opts ...EqualOption) bool {
+	return p.Compare(other, opts...) == 0
+}
+
+// Compare returns -1, 0 or 1 as p sorts before, equal to, or after other.
+// It is a total order over canonicalized PURLs, comparing type, namespace,
+// name, version, qualifiers and subpath in turn. Versions compare as strings,
+// not by version precedence. A nil PURL sorts before any non-nil PURL.
+func (p *PURL) Compare(other *PURL, opts ...EqualOption) int {
+	switch {
+	case p == nil && other == nil:
+		return 0
+	case p == nil:
+		return -1
+	case other == nil:
+		return 1
+	}
+
+	var o equalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	a, b := p.canonical(o), other.canonical(o)
+	if c := strings.Compare(a.Type, b.Type); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Version, b.Version); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Qualifiers.String(), b.Qualifiers.String()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Subpath, b.Subpath)
+}
+
+// canonical returns a normalized copy of the PURL with the components
+// excluded by o cleared. Normalization errors are ignored, as in New.
+func (p *PURL) canonical(o equalOptions) packageurl.PackageURL {
+	c := packageurl.PackageURL{
+		Type:      p.Type,
+		Namespace: p.Namespace,
+		Name:      p.Name,
+		Version:   p.Version,
+		Subpath:   p.Subpath,
+	}
+	if !o.ignoreQualifiers {
+		c.Qualifiers = make(packageurl.Qualifiers, 0, len(p.Qualifiers))
+		for _, q := range p.Qualifiers {
+			if strings.EqualFold(q.Key, "repository_url") && IsDefaultRegistry(strings.ToLower(p.Type), q.Value) {
+				continue
+			}
+			c.Qualifiers = append(c.Qualifiers, q)
+		}
+	}
+	_ = c.Normalize()
+	if o.ignoreVersion {
+		c.Version = ""
+	}
+	if o.ignoreSubpath {
+		c.Subpath = ""
+	}
+	return c
+}
@@ -0,0 +1,126 @@
+package purl
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestCanonicalKey(t *testing.T) {
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash@4.17.21"},
+		{"pkg:npm/lodash?repository_url=https://registry.npmjs.org", "pkg:npm/lodash"},
+		{"pkg:npm/lodash?repository_url=https://npm.example.com", "pkg:npm/lodash?repository_url=https:%2F%2Fnpm.example.com"},
+		{"pkg:deb/debian/curl?distro=jessie&arch=i386", "pkg:deb/debian/curl?arch=i386&distro=jessie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			if got := p.CanonicalKey(); got != tt.want {
+				t.Errorf("CanonicalKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalKeyNormalizesUnparsed(t *testing.T) {
+	p := &PURL{}
+	p.Type = "PyPI"
+	p.Name = "Django_REST"
+	if got, want := p.CanonicalKey(), "pkg:pypi/django-rest"; got != want {
+		t.Errorf("CanonicalKey() = %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalKeyNil(t *testing.T) {
+	var p *PURL
+	if got := p.CanonicalKey(); got != "" {
+		t.Errorf("nil.CanonicalKey() = %q, want empty", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		opts []EqualOption
+		want bool
+	}{
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash@4.17.21", nil, true},
+		{"pkg:npm/lodash", "pkg:npm/lodash?repository_url=https://registry.npmjs.org", nil, true},
+		{"pkg:npm/lodash", "pkg:npm/lodash?repository_url=https://npm.example.com", nil, false},
+		{"pkg:deb/debian/curl?arch=i386&distro=jessie", "pkg:deb/debian/curl?distro=jessie&arch=i386", nil, true},
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash@4.17.20", nil, false},
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash@4.17.20", []EqualOption{IgnoreVersion()}, true},
+		{"pkg:npm/lodash?foo=bar", "pkg:npm/lodash", nil, false},
+		{"pkg:npm/lodash?foo=bar", "pkg:npm/lodash", []EqualOption{IgnoreQualifiers()}, true},
+		{"pkg:golang/google.golang.org/genproto#googleapis/api", "pkg:golang/google.golang.org/genproto", nil, false},
+		{"pkg:golang/google.golang.org/genproto#googleapis/api", "pkg:golang/google.golang.org/genproto", []EqualOption{IgnoreSubpath()}, true},
+		{"pkg:npm/lodash", "pkg:cargo/lodash", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"="+tt.b, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.a, err)
+			}
+			b, err := Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.b, err)
+			}
+			if got := a.Equal(b, tt.opts...); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := b.Equal(a, tt.opts...); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	inputs := []string{
+		"pkg:npm/lodash@4.17.21",
+		"pkg:cargo/serde",
+		"pkg:npm/%40babel/core",
+		"pkg:npm/lodash",
+		"pkg:npm/lodash@4.17.21?foo=bar",
+	}
+	want := []string{
+		"pkg:cargo/serde",
+		"pkg:npm/lodash",
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/lodash@4.17.21?foo=bar",
+		"pkg:npm/%40babel/core",
+	}
+
+	purls := make([]*PURL, len(inputs))
+	for i, s := range inputs {
+		p, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", s, err)
+		}
+		purls[i] = p
+	}
+	sort.Slice(purls, func(i, j int) bool { return purls[i].Compare(purls[j]) < 0 })
+
+	for i, p := range purls {
+		if got := p.String(); got != want[i] {
+			t.Errorf("sorted[%d] = %q, want %q", i, got, want[i])
+		}
+	}
+
+	var nilPURL *PURL
+	if got := nilPURL.Compare(purls[0]); got != -1 {
+		t.Errorf("nil.Compare() = %d, want -1", got)
+	}
+	if got := purls[0].Compare(nil); got != 1 {
+		t.Errorf("Compare(nil) = %d, want 1", got)
+	}
+}
@@ -7,11 +7,22 @@ import (
 
 // IsDefaultRegistry returns true if the registryURL matches the default registry for the type.
 func IsDefaultRegistry(purlType, registryURL string) bool {
+	return defaultRegistry().IsDefaultRegistry(purlType, registryURL)
+}
+
+// IsNonDefaultRegistry returns true if the registryURL is not the default registry for the type.
+func IsNonDefaultRegistry(purlType, registryURL string) bool {
+	return defaultRegistry().IsNonDefaultRegistry(purlType, registryURL)
+}
+
+// IsDefaultRegistry returns true if the registryURL matches the type's
+// default registry in this registry.
+func (r *Registry) IsDefaultRegistry(purlType, registryURL string) bool {
 	if registryURL == "" {
 		return true
 	}
 
-	cfg := TypeInfo(purlType)
+	cfg := r.TypeInfo(purlType)
 	if cfg == nil || cfg.DefaultRegistry == nil {
 		return false
 	}
@@ -32,12 +43,13 @@ func IsDefaultRegistry(purlType, registryURL string) bool {
 	return givenHost == defaultHost || strings.HasSuffix(givenHost, "."+defaultHost)
 }
 
-// IsNonDefaultRegistry returns true if the registryURL is not the default registry for the type.
-func IsNonDefaultRegistry(purlType, registryURL string) bool {
+// IsNonDefaultRegistry returns true if the registryURL is not the type's
+// default registry in this registry.
+func (r *Registry) IsNonDefaultRegistry(purlType, registryURL string) bool {
 	if registryURL == "" {
 		return false
 	}
-	return !IsDefaultRegistry(purlType, registryURL)
+	return !r.IsDefaultRegistry(purlType, registryURL)
 }
 
 // extractHost extracts the hostname from a URL string using net/url.Parse.
@@ -24,6 +24,18 @@
 //	url, _ := p.RegistryURLWithVersion()
 //	fmt.Println(url) // https://www.npmjs.com/package/lodash/v/4.17.21
 //
+//	// The registry's machine-readable metadata endpoint
+//	api, _ := p.RegistryAPIURL()
+//	fmt.Println(api) // https://registry.npmjs.org/lodash
+//
+//	// The artifact itself, honoring qualifiers such as Maven's classifier
+//	dl, _ := p.DownloadURL()
+//	fmt.Println(dl) // https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz
+//
+//	// Parse an artifact URL back to a versioned PURL
+//	p, _ = purl.ParseDownloadURL("https://repo1.maven.org/maven2/junit/junit/4.13.2/junit-4.13.2-sources.jar")
+//	fmt.Println(p.String()) // pkg:maven/junit/junit@4.13.2?classifier=sources
+//
 //	// Parse a registry URL back to a PURL
 //	p, _ := purl.ParseRegistryURL("https://crates.io/crates/serde")
 //	fmt.Println(p.String()) // pkg:cargo/serde
@@ -39,9 +51,81 @@
 //	cfg := purl.TypeInfo("maven")
 //	fmt.Println(cfg.NamespaceRequired()) // true
 //
+// Each type in types.json also has a generated Type constant, such as
+// TypeNPM or TypeMaven, so typos fail to compile:
+//
+//	cfg = purl.TypeMaven.Config()
+//
+// Additional types can be registered at runtime:
+//
+//	err := purl.RegisterType("acme-artifact", purl.TypeConfig{Description: "Acme artifacts"})
+//
+// LoadTypes replaces whole type definitions from a types.json document,
+// while MergeTypes overrides only the fields a document sets, such as a
+// mirror's registry_config.base_url. TypeFieldSources reports where each
+// field of a type's configuration came from. LoadTypeDefinitions reads the
+// upstream purl-spec per-type definition files, keeping this package's
+// registry_config extensions. After loading a custom catalog, Check reports
+// inconsistencies such as templates with unknown placeholders or examples of
+// the wrong type; CatalogError reports a failure to load the embedded data.
+//
+// These package-level functions all act on a shared default registry. For
+// independent configurations, such as per-tenant private registries or
+// parallel test fixtures, create a Registry with NewRegistry and call the
+// same methods on it:
+//
+//	reg := purl.NewRegistry()
+//	err := reg.MergeTypes(overrides)
+//	url, err := reg.RegistryURL(p)
+//
+// Some ecosystems are commonly written with non-canonical type names, such
+// as pkg:rubygems or pkg:go. The catalog records these as aliases, and the
+// CanonicalTypes option makes ParseWithOptions rewrite them and report
+// deprecations:
+//
+//	p, err := purl.ParseWithOptions("pkg:rubygems/rails@7.0.4", purl.CanonicalTypes(func(w purl.TypeWarning) {
+//		log.Println(w)
+//	}))
+//	// p.String() == "pkg:gem/rails@7.0.4"
+//
+// # Validation
+//
+// Validate checks a PURL against its type's rules in types.json, such as
+// whether a namespace is required or prohibited.
+//
+//	err := purl.ValidateString("pkg:maven/junit")
+//	errors.Is(err, purl.ErrNamespaceRequired) // true
+//
+// ValidateQualifiers checks qualifiers against the keys and value patterns
+// each type defines, suggesting the intended key for likely typos:
+//
+//	p, _ := purl.Parse("pkg:maven/junit/junit@4.13.2?clasifier=sources")
+//	err := purl.ValidateQualifiers(p) // unknown qualifier (did you mean "classifier"?)
+//
+// # Version Ranges
+//
+// Satisfies checks a PURL's version against a vers URI or a native
+// constraint, and Pattern matches PURLs against wildcards and ranges.
+//
+//	p, _ := purl.Parse("pkg:npm/lodash@4.17.21")
+//	ok, _ := p.Satisfies("^4.0.0") // true
+//
+//	pat := purl.MustCompilePattern("pkg:npm/@acme/*@vers:npm/>=1.0.0")
+//	pat.MatchString("pkg:npm/%40acme/widgets@1.2.0") // true
+//
 // # Private Registries
 //
 //	p, _ := purl.Parse("pkg:npm/lodash?repository_url=https://npm.example.com")
 //	fmt.Println(p.IsPrivateRegistry()) // true
 //	fmt.Println(p.RepositoryURL())     // https://npm.example.com
+//
+// RegistryURL, RegistryAPIURL and DownloadURL build URLs against a
+// non-default repository_url using the layout of the registry product it
+// points at: Artifactory, Nexus, GitHub Packages, GitLab, Verdaccio, devpi,
+// or any Maven repository. An unrecognized registry returns a
+// *RegistryLayoutError rather than a URL on the public registry.
+//
+//	p, _ = purl.Parse("pkg:npm/lodash@4.17.21?repository_url=https://nexus.example.com/repository/npm-proxy")
+//	dl, _ := p.DownloadURL()
+//	fmt.Println(dl) // https://nexus.example.com/repository/npm-proxy/lodash/-/lodash-4.17.21.tgz
 package purl
@@ -0,0 +1,341 @@
+package purl
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrNoDownloadURL is returned when a PURL type has no download template and
+// the PURL carries no download_url qualifier.
+var ErrNoDownloadURL = errors.New("no download URL for this type")
+
+// DownloadURL returns the location of the package artifact, such as
+// "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz" for
+// pkg:npm/lodash@4.17.21. A download_url qualifier is returned as is.
+// Otherwise the PURL must have a version (ErrNoVersion) and its type a
+// download template (ErrNoDownloadURL). A repository_url is honored as
+// described for RegistryURL.
+func (p *PURL) DownloadURL() (string, error) {
+	return defaultRegistry().DownloadURL(p)
+}
+
+// DownloadURL returns the package artifact location using this registry's
+// download_template for the type.
+//
+// Download templates accept the placeholders of the other registry
+// templates plus qualifier references: {qualifier.KEY} expands to the
+// qualifier's value, falling back to download_qualifier_defaults, and
+// {-qualifier.KEY} expands to "-" and the value, or to nothing when the
+// qualifier is absent or equals its default. Maven uses these for the
+// classifier and type qualifiers, rubygems for the platform suffix.
+func (r *Registry) DownloadURL(p *PURL) (string, error) {
+	if u := p.DownloadURLQualifier(); u != "" {
+		return u, nil
+	}
+
+	cfg := r.TypeInfo(p.Type)
+	if cfg == nil || cfg.RegistryConfig == nil {
+		return "", ErrNoRegistryConfig
+	}
+	rc := cfg.RegistryConfig
+	if p.Version == "" {
+		return "", ErrNoVersion
+	}
+	if repoURL := r.privateRegistry(p); repoURL != "" {
+		return layoutURL(p, rc, repoURL, "download", func(t layoutTemplates) string {
+			return t.download
+		})
+	}
+
+	template := rc.DownloadTemplate
+	if p.Namespace == "" && rc.DownloadTemplateNoNS != "" {
+		template = rc.DownloadTemplateNoNS
+	}
+	if template == "" {
+		return "", ErrNoDownloadURL
+	}
+
+	template = fillQualifiers(template, p.Qualifiers.Map(), rc.DownloadQualifierDefaults)
+	return fillTemplate(template, &rc.Components, p.Namespace, p.Name, p.Version), nil
+}
+
+// fillQualifiers substitutes {qualifier.KEY} and {-qualifier.KEY}
+// placeholders, leaving the others for fillTemplate.
+func fillQualifiers(template string, qualifiers, defaults map[string]string) string {
+	return templatePlaceholder.ReplaceAllStringFunc(template, func(m string) string {
+		placeholder := m[1 : len(m)-1]
+		key := qualifierPlaceholderKey(placeholder)
+		if key == "" {
+			return m
+		}
+		value, def := qualifiers[key], defaults[key]
+		if !strings.HasPrefix(placeholder, "-") {
+			if value == "" {
+				value = def
+			}
+			return url.PathEscape(value)
+		}
+		if value == "" || value == def {
+			return ""
+		}
+		return "-" + url.PathEscape(value)
+	})
+}
+
+// qualifierPlaceholderKey returns the qualifier key named by a
+// "qualifier.KEY" or "-qualifier.KEY" placeholder, or "" for any other
+// placeholder.
+func qualifierPlaceholderKey(placeholder string) string {
+	key, ok := strings.CutPrefix(strings.TrimPrefix(placeholder, "-"), "qualifier.")
+	if !ok {
+		return ""
+	}
+	return key
+}
+
+// ParseDownloadURL parses a package artifact URL into a versioned PURL.
+// See Registry.ParseDownloadURL.
+func ParseDownloadURL(url string) (*PURL, error) {
+	return defaultRegistry().ParseDownloadURL(url)
+}
+
+// ParseDownloadURL parses a package artifact URL, such as an npm tarball or
+// a Maven jar, into a versioned PURL. It tries the download_regex of every
+// type in the registry, in type name order, and returns ErrNoMatch if none
+// matches.
+//
+// The regex's named groups give the PURL components: namespace, name and
+// version, or namespace_path for a namespace written with slashes in place
+// of dots. Any other named group except file becomes a qualifier of the same
+// name. A file group is matched against the last segment of the type's
+// download template, so the template's {qualifier.KEY} references become
+// qualifiers too; that is how a Maven file name yields its classifier and
+// type. Qualifiers equal to their download_qualifier_defaults are dropped.
+func (r *Registry) ParseDownloadURL(url string) (*PURL, error) {
+	r.mu.RLock()
+	candidates := r.downloads
+	r.mu.RUnlock()
+
+	for i := range candidates {
+		if p := candidates[i].parse(url); p != nil {
+			return p, nil
+		}
+	}
+	return nil, ErrNoMatch
+}
+
+// downloadCandidate is one type ParseDownloadURL may try, with the file
+// segments of its download templates split up ahead of time.
+type downloadCandidate struct {
+	purlType string
+	re       *regexp.Regexp
+	rc       *RegistryConfig
+	file     fileTemplate // last segment of download_template
+	fileNoNS fileTemplate // last segment of download_template_no_namespace
+}
+
+// newDownloadIndex returns a candidate for every type in the catalog with a
+// download_regex that compiles, in type name order. compile is the
+// registry's cached regex compiler.
+func newDownloadIndex(c *catalog, compile func(string) (*regexp.Regexp, error)) []downloadCandidate {
+	names := make([]string, 0, len(c.types))
+	for name := range c.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var candidates []downloadCandidate
+	for _, name := range names {
+		rc := c.types[name].cfg.RegistryConfig
+		if rc == nil || rc.DownloadRegex == "" {
+			continue
+		}
+		re, err := compile(rc.DownloadRegex)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, downloadCandidate{
+			purlType: name,
+			re:       re,
+			rc:       rc,
+			file:     newFileTemplate(rc.DownloadTemplate),
+			fileNoNS: newFileTemplate(rc.DownloadTemplateNoNS),
+		})
+	}
+	return candidates
+}
+
+// parse builds a PURL from an artifact URL matching the candidate's
+// download_regex, or returns nil if it doesn't match.
+func (c *downloadCandidate) parse(rawURL string) *PURL {
+	groups, ok := matchGroups(c.re, rawURL)
+	if !ok {
+		return nil
+	}
+
+	var namespace, name, version, file string
+	qualifiers := make(map[string]string)
+	for group, value := range groups {
+		switch group {
+		case "namespace":
+			namespace = value
+		case "namespace_path":
+			namespace = strings.ReplaceAll(value, "/", ".")
+		case "name":
+			name = value
+		case "version":
+			version = value
+		case "file":
+			file = value
+		default:
+			qualifiers[group] = value
+		}
+	}
+
+	if file != "" {
+		template := c.file
+		if namespace == "" && c.rc.DownloadTemplateNoNS != "" {
+			template = c.fileNoNS
+		}
+		values, ok := template.match(file, name, version)
+		if !ok {
+			return nil
+		}
+		for _, v := range values {
+			switch v.group {
+			case "name":
+				name = v.value
+			case "version":
+				version = v.value
+			default:
+				qualifiers[v.group] = v.value
+			}
+		}
+	}
+
+	for key, value := range qualifiers {
+		if value == "" || value == c.rc.DownloadQualifierDefaults[key] {
+			delete(qualifiers, key)
+		}
+	}
+
+	if name == "" || version == "" {
+		return nil
+	}
+	return New(c.purlType, namespace, name, version, qualifiers)
+}
+
+// matchGroups matches s against re and returns its named groups, unescaped.
+func matchGroups(re *regexp.Regexp, s string) (map[string]string, bool) {
+	matches := re.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, false
+	}
+	groups := make(map[string]string)
+	for i, group := range re.SubexpNames() {
+		if group == "" || matches[i] == "" {
+			continue
+		}
+		value, err := url.PathUnescape(matches[i])
+		if err != nil {
+			return nil, false
+		}
+		groups[group] = value
+	}
+	return groups, true
+}
+
+// fileTemplate is the last path segment of a download template, split into
+// literal text and placeholders. Components already known from the rest of
+// the URL must appear literally in a file name; unknown ones and qualifier
+// references are captured. Matching walks the parts directly rather than
+// through a regex, which would have to be rebuilt for every name and
+// version.
+type fileTemplate []fileTemplatePart
+
+// fileTemplatePart is literal text, or a placeholder such as "version" or
+// "-qualifier.classifier" when placeholder is set.
+type fileTemplatePart struct {
+	literal     string
+	placeholder string
+}
+
+// fileValue is a component or qualifier captured from a file name.
+type fileValue struct {
+	group string
+	value string
+}
+
+// newFileTemplate splits the last segment of template into parts.
+func newFileTemplate(template string) fileTemplate {
+	segment := template[strings.LastIndex(template, "/")+1:]
+
+	var parts fileTemplate
+	last := 0
+	for _, loc := range templatePlaceholder.FindAllStringSubmatchIndex(segment, -1) {
+		if loc[0] > last {
+			parts = append(parts, fileTemplatePart{literal: segment[last:loc[0]]})
+		}
+		parts = append(parts, fileTemplatePart{placeholder: segment[loc[2]:loc[3]]})
+		last = loc[1]
+	}
+	if last < len(segment) {
+		parts = append(parts, fileTemplatePart{literal: segment[last:]})
+	}
+	return parts
+}
+
+// match matches file against the template, given the name and version
+// found elsewhere in the URL, and returns the captured values in template
+// order. Captures are as short as possible and optional {-qualifier.KEY}
+// parts are tried present first, so the first match found is the one a
+// regex with lazy groups would give.
+func (ft fileTemplate) match(file, name, version string) ([]fileValue, bool) {
+	known := map[string]string{
+		"name":          name,
+		"name_lower":    strings.ToLower(name),
+		"version":       version,
+		"version_lower": strings.ToLower(version),
+	}
+	return ft.matchFrom(file, known, nil)
+}
+
+func (ft fileTemplate) matchFrom(s string, known map[string]string, values []fileValue) ([]fileValue, bool) {
+	if len(ft) == 0 {
+		return values, s == ""
+	}
+	part, rest := ft[0], ft[1:]
+
+	group := qualifierPlaceholderKey(part.placeholder)
+	literal := part.literal
+	if part.placeholder != "" && group == "" {
+		literal = known[part.placeholder]
+		group = strings.TrimSuffix(part.placeholder, "_lower")
+	}
+	if literal != "" {
+		if !strings.HasPrefix(s, literal) {
+			return nil, false
+		}
+		return rest.matchFrom(s[len(literal):], known, values)
+	}
+
+	if strings.HasPrefix(part.placeholder, "-") {
+		if strings.HasPrefix(s, "-") {
+			for end := 2; end <= len(s); end++ {
+				if got, ok := rest.matchFrom(s[end:], known, append(values, fileValue{group, s[1:end]})); ok {
+					return got, true
+				}
+			}
+		}
+		return rest.matchFrom(s, known, append(values, fileValue{group, ""}))
+	}
+	for end := 1; end <= len(s); end++ {
+		if got, ok := rest.matchFrom(s[end:], known, append(values, fileValue{group, s[:end]})); ok {
+			return got, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,200 @@
+package purl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDownloadURL(t *testing.T) {
+	tests := []struct {
+		purl    string
+		want    string
+		wantErr error
+	}{
+		{"pkg:npm/lodash@4.17.21", "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", nil},
+		{"pkg:npm/%40babel/core@7.24.0", "https://registry.npmjs.org/@babel/core/-/core-7.24.0.tgz", nil},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.14.0",
+			"https://repo.maven.apache.org/maven2/org/apache/commons/commons-lang3/3.14.0/commons-lang3-3.14.0.jar", nil},
+		{"pkg:maven/junit/junit@4.13.2?classifier=sources",
+			"https://repo.maven.apache.org/maven2/junit/junit/4.13.2/junit-4.13.2-sources.jar", nil},
+		{"pkg:maven/org.apache.commons/commons-parent@69?type=pom",
+			"https://repo.maven.apache.org/maven2/org/apache/commons/commons-parent/69/commons-parent-69.pom", nil},
+		{"pkg:cargo/serde@1.0.197", "https://static.crates.io/crates/serde/serde-1.0.197.crate", nil},
+		{"pkg:gem/rails@7.1.3", "https://rubygems.org/downloads/rails-7.1.3.gem", nil},
+		{"pkg:gem/rails@7.1.3?platform=ruby", "https://rubygems.org/downloads/rails-7.1.3.gem", nil},
+		{"pkg:gem/nokogiri@1.16.2?platform=x86_64-linux", "https://rubygems.org/downloads/nokogiri-1.16.2-x86_64-linux.gem", nil},
+		{"pkg:nuget/Newtonsoft.Json@13.0.3-Beta1",
+			"https://api.nuget.org/v3-flatcontainer/newtonsoft.json/13.0.3-beta1/newtonsoft.json.13.0.3-beta1.nupkg", nil},
+		{"pkg:generic/openssl@1.1.10g?download_url=https://openssl.org/source/openssl-1.1.0g.tar.gz",
+			"https://openssl.org/source/openssl-1.1.0g.tar.gz", nil},
+		{"pkg:npm/lodash@4.17.21?download_url=https://mirror.example.com/lodash.tgz", "https://mirror.example.com/lodash.tgz", nil},
+		{"pkg:npm/lodash", "", ErrNoVersion},
+		{"pkg:pypi/requests@2.31.0", "", ErrNoDownloadURL},
+		{"pkg:generic/openssl@1.1.10g", "", ErrNoRegistryConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			got, err := p.DownloadURL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DownloadURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DownloadURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillQualifiers(t *testing.T) {
+	defaults := map[string]string{"type": "jar"}
+	tests := []struct {
+		qualifiers map[string]string
+		want       string
+	}{
+		{nil, "a.jar"},
+		{map[string]string{"type": "jar"}, "a.jar"},
+		{map[string]string{"type": "war"}, "a.war"},
+		{map[string]string{"classifier": "tests"}, "a-tests.jar"},
+		{map[string]string{"classifier": "a b"}, "a-a%20b.jar"},
+	}
+	for _, tt := range tests {
+		got := fillQualifiers("{name}{-qualifier.classifier}.{qualifier.type}", tt.qualifiers, defaults)
+		if want := strings.Replace(tt.want, "a", "{name}", 1); got != want {
+			t.Errorf("fillQualifiers(%v) = %q, want %q", tt.qualifiers, got, want)
+		}
+	}
+}
+
+func TestParseDownloadURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", "pkg:npm/lodash@4.17.21"},
+		{"https://registry.npmjs.org/@babel/core/-/core-7.24.0.tgz", "pkg:npm/%40babel/core@7.24.0"},
+		{"https://registry.yarnpkg.com/@types/node/-/node-20.11.5.tgz", "pkg:npm/%40types/node@20.11.5"},
+		{"https://files.pythonhosted.org/packages/70/8e/0e2d847013cb52cd35b38c009bb167a1a26b2ce6cd6965bf26b47bc0bf44/requests-2.31.0-py3-none-any.whl",
+			"pkg:pypi/requests@2.31.0?file_name=requests-2.31.0-py3-none-any.whl"},
+		{"https://files.pythonhosted.org/packages/source/p/python-dateutil/python-dateutil-2.8.2.tar.gz",
+			"pkg:pypi/python-dateutil@2.8.2?file_name=python-dateutil-2.8.2.tar.gz"},
+		{"https://repo1.maven.org/maven2/org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.jar", "pkg:maven/org.slf4j/slf4j-api@2.0.9"},
+		{"https://repo.maven.apache.org/maven2/junit/junit/4.13.2/junit-4.13.2-sources.jar",
+			"pkg:maven/junit/junit@4.13.2?classifier=sources"},
+		{"https://repo1.maven.org/maven2/org/apache/commons/commons-parent/69/commons-parent-69.pom",
+			"pkg:maven/org.apache.commons/commons-parent@69?type=pom"},
+		{"https://static.crates.io/crates/serde/serde-1.0.0.crate", "pkg:cargo/serde@1.0.0"},
+		{"https://static.crates.io/crates/serde_json/serde_json-1.0.114.crate", "pkg:cargo/serde_json@1.0.114"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			p, err := ParseDownloadURL(tt.url)
+			if err != nil {
+				t.Fatalf("ParseDownloadURL() error: %v", err)
+			}
+			if got := p.String(); got != tt.want {
+				t.Errorf("ParseDownloadURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDownloadURLNoMatch(t *testing.T) {
+	urls := []string{
+		"https://www.npmjs.com/package/lodash",
+		"https://repo1.maven.org/maven2/org/slf4j/slf4j-api/2.0.9/other-2.0.9.jar",
+		"https://static.crates.io/crates/serde/serde.crate",
+		"https://example.com/lodash-4.17.21.tgz",
+	}
+	for _, u := range urls {
+		if _, err := ParseDownloadURL(u); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("ParseDownloadURL(%q) error = %v, want ErrNoMatch", u, err)
+		}
+	}
+}
+
+func TestFileTemplateMatch(t *testing.T) {
+	maven := newFileTemplate("https://repo.example/{name}-{version}{-qualifier.classifier}.{qualifier.type}")
+	tests := []struct {
+		file    string
+		version string
+		want    string
+		wantOK  bool
+	}{
+		{"commons-lang3-3.12.0.jar", "3.12.0", "classifier= type=jar", true},
+		{"commons-lang3-3.12.0-sources.jar", "3.12.0", "classifier=sources type=jar", true},
+		{"commons-lang3-3.12.0-tests.test-jar", "3.12.0", "classifier=tests type=test-jar", true},
+		{"other-3.12.0.jar", "3.12.0", "", false},
+		{"commons-lang3-3.12.0", "3.12.0", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			values, ok := maven.match(tt.file, "commons-lang3", tt.version)
+			if ok != tt.wantOK {
+				t.Fatalf("match() ok = %v, want %v", ok, tt.wantOK)
+			}
+			var got []string
+			for _, v := range values {
+				got = append(got, v.group+"="+v.value)
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("match() = %q, want %q", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDownloadURLRegisteredType(t *testing.T) {
+	r := NewRegistry()
+	err := r.RegisterType("acme", TypeConfig{RegistryConfig: &RegistryConfig{
+		DownloadTemplate: "https://dl.acme.example/{name}/{name}-{version}.zip",
+		DownloadRegex:    `^https://dl\.acme\.example/(?P<name>[^/]+)/(?P<file>[^/]+)$`,
+	}})
+	if err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+
+	p, err := r.ParseDownloadURL("https://dl.acme.example/anvil/anvil-2.1.zip")
+	if err != nil {
+		t.Fatalf("ParseDownloadURL() error: %v", err)
+	}
+	if got := p.String(); got != "pkg:acme/anvil@2.1" {
+		t.Errorf("ParseDownloadURL() = %q, want pkg:acme/anvil@2.1", got)
+	}
+	if _, err := ParseDownloadURL("https://dl.acme.example/anvil/anvil-2.1.zip"); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("default ParseDownloadURL() error = %v, want ErrNoMatch", err)
+	}
+}
+
+func TestParseDownloadURLRoundTrip(t *testing.T) {
+	purls := []string{
+		"pkg:npm/%40babel/core@7.24.0",
+		"pkg:maven/junit/junit@4.13.2?classifier=sources",
+		"pkg:maven/org.apache.commons/commons-parent@69?type=pom",
+		"pkg:cargo/serde@1.0.197",
+	}
+	for _, s := range purls {
+		p, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%s) error: %v", s, err)
+		}
+		u, err := p.DownloadURL()
+		if err != nil {
+			t.Fatalf("DownloadURL(%s) error: %v", s, err)
+		}
+		back, err := ParseDownloadURL(u)
+		if err != nil {
+			t.Fatalf("ParseDownloadURL(%q) error: %v", u, err)
+		}
+		if !back.Equal(p) {
+			t.Errorf("ParseDownloadURL(%q) = %s, want %s", u, back, s)
+		}
+	}
+}
@@ -15,57 +15,55 @@ const (
 	ecosystemPackagist     = "packagist"
 	ecosystemRubyGems      = "rubygems"
 	ecosystemSwift         = "swift"
-	purlTypeGem            = "gem"
-	purlTypeGitHubActions  = "githubactions"
 )
 
 // purlTypeForEcosystem maps ecosystem names to PURL types.
 // Most ecosystems use their name as the PURL type, but some differ.
 var purlTypeForEcosystem = map[string]string{
-	ecosystemAlpine:        "apk",
-	ecosystemArch:          "alpm",
-	ecosystemRubyGems:      purlTypeGem,
-	ecosystemPackagist:     ecosystemComposer,
-	ecosystemGitHubActions: purlTypeGitHubActions,
+	ecosystemAlpine:        string(TypeAPK),
+	ecosystemArch:          string(TypeALPM),
+	ecosystemRubyGems:      string(TypeGem),
+	ecosystemPackagist:     string(TypeComposer),
+	ecosystemGitHubActions: string(TypeGitHubActions),
 }
 
 // ecosystemAliases maps alternate names to canonical ecosystem names.
 var ecosystemAliases = map[string]string{
-	"go":              ecosystemGolang,
-	purlTypeGem:       ecosystemRubyGems,
-	ecosystemComposer: ecosystemPackagist,
+	"go":                 ecosystemGolang,
+	string(TypeGem):      ecosystemRubyGems,
+	string(TypeComposer): ecosystemPackagist,
 }
 
 // osvEcosystemNames maps PURL types to OSV ecosystem names.
-var osvEcosystemNames = map[string]string{
-	purlTypeGem:           "RubyGems",
-	ecosystemNPM:          ecosystemNPM,
-	"pypi":                "PyPI",
-	"cargo":               "crates.io",
-	"conan":               "ConanCenter",
-	"cran":                "CRAN",
-	ecosystemGolang:       "Go",
-	"hackage":             "Hackage",
-	ecosystemMaven:        "Maven",
-	"julia":               "Julia",
-	"nuget":               "NuGet",
-	"opam":                "opam",
-	ecosystemComposer:     "Packagist",
-	"hex":                 "Hex",
-	"pub":                 "Pub",
-	"swift":               "SwiftURL",
-	purlTypeGitHubActions: "GitHub Actions",
+var osvEcosystemNames = map[Type]string{
+	TypeGem:           "RubyGems",
+	TypeNPM:           ecosystemNPM,
+	TypePyPI:          "PyPI",
+	TypeCargo:         "crates.io",
+	TypeConan:         "ConanCenter",
+	TypeCRAN:          "CRAN",
+	TypeGolang:        "Go",
+	TypeHackage:       "Hackage",
+	TypeMaven:         "Maven",
+	"julia":           "Julia",
+	TypeNuGet:         "NuGet",
+	"opam":            "opam",
+	TypeComposer:      "Packagist",
+	TypeHex:           "Hex",
+	TypePub:           "Pub",
+	TypeSwift:         "SwiftURL",
+	TypeGitHubActions: "GitHub Actions",
 }
 
 // depsdevSystemNames maps PURL types to deps.dev system names.
-var depsdevSystemNames = map[string]string{
-	ecosystemNPM:    "NPM",
-	purlTypeGem:     "RUBYGEMS",
-	"pypi":          "PYPI",
-	"cargo":         "CARGO",
-	ecosystemGolang: "GO",
-	ecosystemMaven:  "MAVEN",
-	"nuget":         "NUGET",
+var depsdevSystemNames = map[Type]string{
+	TypeNPM:    "NPM",
+	TypeGem:    "RUBYGEMS",
+	TypePyPI:   "PYPI",
+	TypeCargo:  "CARGO",
+	TypeGolang: "GO",
+	TypeMaven:  "MAVEN",
+	TypeNuGet:  "NUGET",
 }
 
 // defaultNamespaces defines default namespaces for certain ecosystems.
@@ -110,7 +108,7 @@ func PURLTypeToEcosystem(purlType string) string {
 // OSV uses specific capitalization and naming conventions.
 func EcosystemToOSV(ecosystem string) string {
 	purlType := EcosystemToPURLType(ecosystem)
-	if osv, ok := osvEcosystemNames[purlType]; ok {
+	if osv, ok := osvEcosystemNames[Type(purlType)]; ok {
 		return osv
 	}
 	return ecosystem
@@ -124,14 +122,14 @@ func EcosystemToOSV(ecosystem string) string {
 // back to a GIT range instead of writing an ecosystem the OSV schema will
 // reject.
 func PURLTypeToOSV(purlType string) (string, bool) {
-	osv, ok := osvEcosystemNames[purlType]
+	osv, ok := osvEcosystemNames[Type(purlType)]
 	return osv, ok
 }
 
 // PURLTypeToDepsdev converts a PURL type to the deps.dev system name.
 // Returns empty string if the type is not supported by deps.dev.
 func PURLTypeToDepsdev(purlType string) string {
-	if system, ok := depsdevSystemNames[purlType]; ok {
+	if system, ok := depsdevSystemNames[Type(purlType)]; ok {
 		return system
 	}
 	return ""
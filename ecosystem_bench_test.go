@@ -37,3 +37,33 @@ func BenchmarkMakePURLString(b *testing.B) {
 		})
 	}
 }
+
+var benchmarkRegistryPURL *PURL
+
+func BenchmarkParseRegistryURL(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		url  string
+	}{
+		{name: "npm", url: "https://www.npmjs.com/package/lodash/v/4.17.21"},
+		{name: "maven", url: "https://mvnrepository.com/artifact/org.apache.commons/commons-lang3/3.12.0"},
+		{name: "swift", url: "https://swiftpackageindex.com/apple/swift-argument-parser"},
+		{name: "no_match", url: "https://example.com/unknown"},
+	}
+
+	r := NewRegistry()
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				benchmarkRegistryPURL, _ = r.ParseRegistryURL(benchmark.url)
+			}
+		})
+		b.Run(benchmark.name+"_scan", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				benchmarkRegistryPURL, _ = parseRegistryURLScan(r, benchmark.url)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package purl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// Object is the structured form of a PURL, for APIs and config files that
+// prefer separate fields to the canonical string. PURL itself encodes as a
+// string; use Object where the structured form is wanted.
+type Object struct {
+	Type       string            `json:"type" yaml:"type"`
+	Namespace  string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name       string            `json:"name" yaml:"name"`
+	Version    string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Qualifiers map[string]string `json:"qualifiers,omitempty" yaml:"qualifiers,omitempty"`
+	Subpath    string            `json:"subpath,omitempty" yaml:"subpath,omitempty"`
+}
+
+// Object returns the structured form of the PURL.
+func (p *PURL) Object() Object {
+	o := Object{
+		Type:      p.Type,
+		Namespace: p.Namespace,
+		Name:      p.Name,
+		Version:   p.Version,
+		Subpath:   p.Subpath,
+	}
+	if len(p.Qualifiers) > 0 {
+		o.Qualifiers = p.Qualifiers.Map()
+	}
+	return o
+}
+
+// PURL converts the structured form back to a PURL, applying the same
+// normalization and validation as Parse.
+func (o Object) PURL() (*PURL, error) {
+	return Parse(o.pkg().String())
+}
+
+func (o Object) pkg() packageurl.PackageURL {
+	var q packageurl.Qualifiers
+	if len(o.Qualifiers) > 0 {
+		q = packageurl.QualifiersFromMap(o.Qualifiers)
+	}
+	return packageurl.PackageURL{
+		Type:       o.Type,
+		Namespace:  o.Namespace,
+		Name:       o.Name,
+		Version:    o.Version,
+		Qualifiers: q,
+		Subpath:    o.Subpath,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical PURL
+// string. YAML libraries that honour TextMarshaler use it as well. The zero
+// PURL encodes as an empty string.
+func (p PURL) MarshalText() ([]byte, error) {
+	if p.Type == "" && p.Name == "" {
+		return []byte{}, nil
+	}
+	return []byte(p.PackageURL.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with the same rules as
+// Parse. An empty input leaves the zero PURL.
+func (p *PURL) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = PURL{}
+		return nil
+	}
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = *parsed
+	return nil
+}
+
+// MarshalJSON encodes the PURL as its canonical string.
+func (p PURL) MarshalJSON() ([]byte, error) {
+	text, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a PURL from either its canonical string or the
+// structured Object form. JSON null leaves the PURL unchanged.
+func (p *PURL) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var o Object
+		if err := json.Unmarshal(data, &o); err != nil {
+			return err
+		}
+		parsed, err := o.PURL()
+		if err != nil {
+			return err
+		}
+		*p = *parsed
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("purl: expected string or object: %w", err)
+	}
+	return p.UnmarshalText([]byte(s))
+}
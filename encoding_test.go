@@ -0,0 +1,119 @@
+package purl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPURLTextRoundTrip(t *testing.T) {
+	p, _ := Parse("pkg:npm/%40babel/core@7.24.0?repository_url=https://npm.example.com")
+
+	text, err := p.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error: %v", err)
+	}
+	if got, want := string(text), p.String(); got != want {
+		t.Errorf("MarshalText() = %q, want %q", got, want)
+	}
+
+	var decoded PURL
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText() error: %v", err)
+	}
+	if decoded.String() != p.String() {
+		t.Errorf("round trip = %q, want %q", decoded.String(), p.String())
+	}
+
+	if err := decoded.UnmarshalText([]byte("not a purl")); err == nil {
+		t.Error("UnmarshalText() error = nil, want error")
+	}
+}
+
+func TestPURLJSON(t *testing.T) {
+	type record struct {
+		Package  PURL  `json:"package"`
+		Optional *PURL `json:"optional,omitempty"`
+	}
+
+	p, _ := Parse("pkg:cargo/serde@1.0.0")
+	data, err := json.Marshal(record{Package: *p})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if got, want := string(data), `{"package":"pkg:cargo/serde@1.0.0"}`; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+
+	var decoded record
+	if err := json.Unmarshal([]byte(`{"package":"pkg:cargo/serde@1.0.0","optional":null}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if got := decoded.Package.String(); got != "pkg:cargo/serde@1.0.0" {
+		t.Errorf("Package = %q, want %q", got, "pkg:cargo/serde@1.0.0")
+	}
+	if decoded.Optional != nil {
+		t.Errorf("Optional = %v, want nil", decoded.Optional)
+	}
+
+	if err := json.Unmarshal([]byte(`{"package":"bogus"}`), &decoded); err == nil {
+		t.Error("Unmarshal invalid purl error = nil, want error")
+	}
+	if err := json.Unmarshal([]byte(`{"package":42}`), &decoded); err == nil {
+		t.Error("Unmarshal number error = nil, want error")
+	}
+}
+
+func TestPURLJSONMapKeys(t *testing.T) {
+	p, _ := Parse("pkg:npm/lodash@4.17.21")
+	data, err := json.Marshal(map[*PURL]int{p: 1})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if got, want := string(data), `{"pkg:npm/lodash@4.17.21":1}`; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+}
+
+func TestObject(t *testing.T) {
+	p, _ := Parse("pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie#usr/bin")
+	want := Object{
+		Type:       "deb",
+		Namespace:  "debian",
+		Name:       "curl",
+		Version:    "7.50.3-1",
+		Qualifiers: map[string]string{"arch": "i386", "distro": "jessie"},
+		Subpath:    "usr/bin",
+	}
+	o := p.Object()
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("Object() = %+v, want %+v", o, want)
+	}
+
+	data, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var decoded PURL
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal object error: %v", err)
+	}
+	if decoded.String() != p.String() {
+		t.Errorf("object round trip = %q, want %q", decoded.String(), p.String())
+	}
+
+	if _, err := (Object{Type: "npm"}).PURL(); err == nil {
+		t.Error("Object{}.PURL() error = nil, want error for missing name")
+	}
+}
+
+func TestZeroPURLText(t *testing.T) {
+	var p PURL
+	text, err := p.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("zero MarshalText() = %q, %v; want empty", text, err)
+	}
+	if err := p.UnmarshalText(nil); err != nil {
+		t.Errorf("UnmarshalText(nil) error: %v", err)
+	}
+}
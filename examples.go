@@ -0,0 +1,66 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidExample is returned when an example in the type catalog does not
+// parse, belongs to a different type, or does not survive a round trip
+// through String and Parse.
+var ErrInvalidExample = errors.New("invalid example")
+
+// ExamplesFor returns the parsed examples for a PURL type. See
+// Registry.ExamplesFor.
+func ExamplesFor(purlType string) ([]*PURL, error) {
+	return defaultRegistry().ExamplesFor(purlType)
+}
+
+// ExamplesFor parses the examples recorded for a PURL type and checks that
+// each one has the declared type and round-trips: parsing its String form
+// gives an equal PURL. Valid examples are returned in catalog order. Invalid
+// ones are left out and reported together, joined with errors.Join, each
+// naming the example and wrapping ErrInvalidExample. An unknown type returns
+// ErrUnknownType.
+func (r *Registry) ExamplesFor(purlType string) ([]*PURL, error) {
+	cfg, ok := r.lookupType(purlType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, purlType)
+	}
+
+	examples := make([]*PURL, 0, len(cfg.Examples))
+	var errs []error
+	for _, ex := range cfg.Examples {
+		p, err := checkExample(purlType, ex)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		examples = append(examples, p)
+	}
+	return examples, errors.Join(errs...)
+}
+
+// checkExample parses one catalog example for purlType.
+func checkExample(purlType, example string) (*PURL, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s example %q: %s", ErrInvalidExample, purlType, example, fmt.Sprintf(format, args...))
+	}
+
+	p, err := Parse(example)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s example %q: %w", ErrInvalidExample, purlType, example, err)
+	}
+	if p.Type != purlType {
+		return nil, invalid("has type %q", p.Type)
+	}
+	s := p.String()
+	again, err := Parse(s)
+	if err != nil {
+		return nil, invalid("does not round-trip, %q fails to parse: %v", s, err)
+	}
+	if got := again.String(); got != s {
+		return nil, invalid("does not round-trip, %q reparses as %q", s, got)
+	}
+	return p, nil
+}
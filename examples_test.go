@@ -0,0 +1,60 @@
+package purl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExamplesFor(t *testing.T) {
+	examples, err := ExamplesFor("npm")
+	if err != nil {
+		t.Fatalf("ExamplesFor(npm) error: %v", err)
+	}
+	if len(examples) != len(TypeInfo("npm").Examples) {
+		t.Errorf("ExamplesFor(npm) returned %d examples, want %d", len(examples), len(TypeInfo("npm").Examples))
+	}
+	for _, p := range examples {
+		if p.Type != "npm" {
+			t.Errorf("example %s has type %q", p, p.Type)
+		}
+	}
+
+	if _, err := ExamplesFor("not-a-type"); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("ExamplesFor(unknown) error = %v, want ErrUnknownType", err)
+	}
+}
+
+func TestExamplesForEmbedded(t *testing.T) {
+	for _, typ := range KnownTypes() {
+		if _, err := ExamplesFor(typ); err != nil {
+			t.Errorf("ExamplesFor(%s) error: %v", typ, err)
+		}
+	}
+}
+
+func TestExamplesForInvalid(t *testing.T) {
+	r := NewRegistry()
+	doc := `{"types": {"npm": {"examples": [
+		"pkg:npm/lodash@4.17.21",
+		"pkg:pypi/django@4.2",
+		"pkg:cpan/Moose@2.2014",
+		"not a purl"
+	]}}}`
+	if err := r.MergeTypes(strings.NewReader(doc)); err != nil {
+		t.Fatalf("MergeTypes() error: %v", err)
+	}
+
+	examples, err := r.ExamplesFor("npm")
+	if len(examples) != 1 || examples[0].String() != "pkg:npm/lodash@4.17.21" {
+		t.Errorf("ExamplesFor() = %v, want only the valid example", examples)
+	}
+	if !errors.Is(err, ErrInvalidExample) {
+		t.Fatalf("ExamplesFor() error = %v, want ErrInvalidExample", err)
+	}
+	for _, bad := range []string{"pkg:pypi/django@4.2", "pkg:cpan/Moose@2.2014", "not a purl"} {
+		if !strings.Contains(err.Error(), bad) {
+			t.Errorf("error %q does not name %q", err, bad)
+		}
+	}
+}
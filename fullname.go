@@ -7,7 +7,7 @@ func (p *PURL) FullName() string {
 	if p.Namespace == "" {
 		return p.Name
 	}
-	if p.Type == "maven" {
+	if Type(p.Type) == TypeMaven {
 		return p.Namespace + ":" + p.Name
 	}
 	return p.Namespace + "/" + p.Name
@@ -0,0 +1,213 @@
+package purl
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+)
+
+// maxHostPrefixes bounds the literal prefixes enumerated for one
+// reverse_regex. Patterns with more alternatives are treated as matching
+// any host.
+const maxHostPrefixes = 16
+
+// hostIndex maps URL hosts to the types whose reverse_regex can match a URL
+// on that host, so ParseRegistryURL only tries a few patterns per URL. It is
+// rebuilt whenever a registry's catalog changes and never modified after.
+type hostIndex struct {
+	byHost  map[string][]registryCandidate
+	anyHost []registryCandidate // patterns whose host could not be determined
+}
+
+// registryCandidate is one type ParseRegistryURL may try.
+type registryCandidate struct {
+	purlType string
+	re       *regexp.Regexp
+	comp     RegistryComponents
+}
+
+// newHostIndex indexes the reverse_regex of every type in the catalog. The
+// candidates for each host, including those that match any host, are kept
+// in type name order so the first match is the one a scan of KnownTypes
+// would find. compile is the registry's cached regex compiler, so the
+// patterns are shared with ParseRegistryURLWithType.
+func newHostIndex(c *catalog, compile func(string) (*regexp.Regexp, error)) *hostIndex {
+	names := make([]string, 0, len(c.types))
+	for name := range c.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	idx := &hostIndex{byHost: make(map[string][]registryCandidate)}
+	for _, name := range names {
+		rc := c.types[name].cfg.RegistryConfig
+		if rc == nil || rc.ReverseRegex == "" {
+			continue
+		}
+		re, err := compile(rc.ReverseRegex)
+		if err != nil {
+			continue
+		}
+		cand := registryCandidate{purlType: name, re: re, comp: rc.Components}
+		hosts, ok := patternHosts(rc.ReverseRegex)
+		if !ok {
+			idx.anyHost = append(idx.anyHost, cand)
+			continue
+		}
+		for _, host := range hosts {
+			idx.byHost[host] = append(idx.byHost[host], cand)
+		}
+	}
+
+	if len(idx.anyHost) > 0 {
+		for host, cands := range idx.byHost {
+			merged := append(cands, idx.anyHost...)
+			sort.SliceStable(merged, func(i, j int) bool { return merged[i].purlType < merged[j].purlType })
+			idx.byHost[host] = merged
+		}
+	}
+	return idx
+}
+
+// candidates returns the types to try for rawURL.
+func (idx *hostIndex) candidates(rawURL string) []registryCandidate {
+	if idx == nil {
+		return nil
+	}
+	host, _ := urlHost(rawURL)
+	if cands, ok := idx.byHost[host]; ok {
+		return cands
+	}
+	return idx.anyHost
+}
+
+// urlHost returns the part of s between "://" and the next "/", "?" or
+// "#", and whether such a terminator was found.
+func urlHost(s string) (string, bool) {
+	_, rest, ok := strings.Cut(s, "://")
+	if !ok {
+		return "", false
+	}
+	i := strings.IndexAny(rest, "/?#")
+	if i < 0 {
+		return rest, false
+	}
+	return rest[:i], true
+}
+
+// patternHosts returns every host a URL matched by pattern can have. It
+// reports false when that can't be determined from the pattern's literal
+// prefixes, for example when it is unanchored or the host is a wildcard.
+func patternHosts(pattern string) ([]string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil || !anchored(re) {
+		return nil, false
+	}
+	prefixes, _ := literalPrefixes(re)
+	if prefixes == nil {
+		return nil, false
+	}
+
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, prefix := range prefixes {
+		host, ok := urlHost(prefix)
+		if !ok {
+			return nil, false
+		}
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, true
+}
+
+// anchored reports whether re only matches at the start of the text.
+func anchored(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginText:
+		return true
+	case syntax.OpConcat:
+		return len(re.Sub) > 0 && anchored(re.Sub[0])
+	case syntax.OpCapture:
+		return anchored(re.Sub[0])
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if !anchored(sub) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// literalPrefixes returns a set of strings such that every match of re
+// begins with one of them, and whether they are exactly the strings re
+// matches. It returns nil if the set would exceed maxHostPrefixes.
+func literalPrefixes(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginText, syntax.OpBeginLine:
+		return []string{""}, true
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return []string{""}, false
+		}
+		return []string{string(re.Rune)}, true
+	case syntax.OpCharClass:
+		var runes []string
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(runes) == maxHostPrefixes {
+					return []string{""}, false
+				}
+				runes = append(runes, string(r))
+			}
+		}
+		return runes, true
+	case syntax.OpCapture:
+		return literalPrefixes(re.Sub[0])
+	case syntax.OpQuest:
+		prefixes, complete := literalPrefixes(re.Sub[0])
+		if prefixes == nil {
+			return nil, false
+		}
+		return append(prefixes, ""), complete
+	case syntax.OpConcat:
+		out := []string{""}
+		for _, sub := range re.Sub {
+			prefixes, complete := literalPrefixes(sub)
+			if prefixes == nil || len(out)*len(prefixes) > maxHostPrefixes {
+				return nil, false
+			}
+			next := make([]string, 0, len(out)*len(prefixes))
+			for _, a := range out {
+				for _, b := range prefixes {
+					next = append(next, a+b)
+				}
+			}
+			out = next
+			if !complete {
+				return out, false
+			}
+		}
+		return out, true
+	case syntax.OpAlternate:
+		var out []string
+		complete := true
+		for _, sub := range re.Sub {
+			prefixes, c := literalPrefixes(sub)
+			if prefixes == nil || len(out)+len(prefixes) > maxHostPrefixes {
+				return nil, false
+			}
+			out = append(out, prefixes...)
+			complete = complete && c
+		}
+		return out, complete
+	default:
+		return []string{""}, false
+	}
+}
@@ -0,0 +1,136 @@
+package purl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPatternHosts(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+		wantOK  bool
+	}{
+		{`^https://crates\.io/crates/([^/?#]+)`, []string{"crates.io"}, true},
+		{`^https://(?:www\.)?npmjs\.com/package/([^/?#]+)`, []string{"www.npmjs.com", "npmjs.com"}, true},
+		{`^https?://(?:a|b)\.example/([^/]+)`, []string{"a.example", "b.example"}, true},
+		{`^https://([^/]+)\.example/([^/]+)`, nil, false},
+		{`^https://example\.com`, nil, false},
+		{`https://example\.com/([^/]+)`, nil, false},
+		{`^(?i)https://example\.com/([^/]+)`, nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := patternHosts(tt.pattern)
+		if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("patternHosts(%q) = %v, %v, want %v, %v", tt.pattern, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestEmbeddedReverseRegexesIndexed(t *testing.T) {
+	for _, c := range NewRegistry().hosts.anyHost {
+		t.Errorf("%s reverse_regex %q is not indexed by host", c.purlType, c.re)
+	}
+}
+
+// parseRegistryURLScan is ParseRegistryURL without the host index.
+func parseRegistryURLScan(r *Registry, url string) (*PURL, error) {
+	for _, t := range r.KnownTypes() {
+		if p, err := r.ParseRegistryURLWithType(url, t); err == nil {
+			return p, nil
+		}
+	}
+	return nil, ErrNoMatch
+}
+
+func TestParseRegistryURLMatchesScan(t *testing.T) {
+	r := NewRegistry()
+	urls := []string{
+		"https://example.com/unknown",
+		"https://crates.io",
+		"not a url",
+	}
+	for _, typ := range r.KnownTypes() {
+		examples, _ := r.ExamplesFor(typ)
+		for _, p := range examples {
+			if u, err := r.RegistryURL(p); err == nil {
+				urls = append(urls, u)
+			}
+			if u, err := r.RegistryURLWithVersion(p); err == nil {
+				urls = append(urls, u)
+			}
+		}
+	}
+
+	for _, u := range urls {
+		got, gotErr := r.ParseRegistryURL(u)
+		want, wantErr := parseRegistryURLScan(r, u)
+		if (gotErr != nil) != (wantErr != nil) || (got != nil && !got.Equal(want)) {
+			t.Errorf("ParseRegistryURL(%q) = %v, %v, scan gives %v, %v", u, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestHostIndexFollowsCatalog(t *testing.T) {
+	r := NewRegistry()
+	acme := "https://artifacts.acme.example/packages/widgets"
+	if _, err := r.ParseRegistryURL(acme); err == nil {
+		t.Fatalf("ParseRegistryURL(%q) matched before registering", acme)
+	}
+
+	err := r.RegisterType("acme", TypeConfig{RegistryConfig: &RegistryConfig{
+		ReverseRegex: `^https://artifacts\.acme\.example/packages/([^/?#]+)`,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err := r.ParseRegistryURL(acme); err != nil || p.String() != "pkg:acme/widgets" {
+		t.Errorf("ParseRegistryURL(%q) after RegisterType = %v, %v", acme, p, err)
+	}
+
+	err = r.MergeTypes(strings.NewReader(`{"types": {"cargo": {"registry_config": {
+		"reverse_regex": "^https://crates\\.acme\\.example/crates/([^/?#]+)"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ParseRegistryURL("https://crates.io/crates/serde"); err == nil {
+		t.Error("ParseRegistryURL matched crates.io after MergeTypes moved cargo")
+	}
+	if p, err := r.ParseRegistryURL("https://crates.acme.example/crates/serde"); err != nil || p.String() != "pkg:cargo/serde" {
+		t.Errorf("ParseRegistryURL after MergeTypes = %v, %v", p, err)
+	}
+}
+
+func TestHostIndexAnyHost(t *testing.T) {
+	var r Registry
+	for _, name := range []string{"aaa", "zzz"} {
+		err := r.RegisterType(name, TypeConfig{RegistryConfig: &RegistryConfig{
+			ReverseRegex: `^https://registry\.example/` + name + `/([^/?#]+)`,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := r.RegisterType("mmm", TypeConfig{RegistryConfig: &RegistryConfig{
+		ReverseRegex: `^https://[^/]+/(?:aaa|zzz|mmm)/([^/?#]+)`,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://registry.example/aaa/widgets", "pkg:aaa/widgets"},
+		{"https://registry.example/zzz/widgets", "pkg:mmm/widgets"},
+		{"https://other.example/mmm/widgets", "pkg:mmm/widgets"},
+	}
+	for _, tt := range tests {
+		p, err := r.ParseRegistryURL(tt.url)
+		if err != nil || p.String() != tt.want {
+			t.Errorf("ParseRegistryURL(%q) = %v, %v, want %s", tt.url, p, err, tt.want)
+		}
+	}
+}
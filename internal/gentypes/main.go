@@ -0,0 +1,160 @@
+// Command gentypes generates the Type constants in typeconsts.go from
+// types.json. It is run by go generate in the purl package:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// initialisms gives the constant suffix for type names that are acronyms or
+// brand names, following Go's naming conventions. Other names are title-cased.
+var initialisms = map[string]string{
+	"alpm":          "ALPM",
+	"apk":           "APK",
+	"cpan":          "CPAN",
+	"cran":          "CRAN",
+	"github":        "GitHub",
+	"githubactions": "GitHubActions",
+	"mlflow":        "MLflow",
+	"npm":           "NPM",
+	"nuget":         "NuGet",
+	"oci":           "OCI",
+	"pypi":          "PyPI",
+	"qpkg":          "QPKG",
+	"rpm":           "RPM",
+	"swid":          "SWID",
+}
+
+func main() {
+	in := flag.String("i", "types.json", "types.json to read")
+	out := flag.String("o", "typeconsts.go", "Go file to write")
+	flag.Parse()
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := render(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil { //nolint:gosec,mnd
+		log.Fatal(err)
+	}
+}
+
+type constant struct {
+	Name        string
+	Value       string
+	Description string
+}
+
+// render returns the formatted Go source for the types in a types.json document.
+func render(data []byte) ([]byte, error) {
+	var doc struct {
+		Types map[string]struct {
+			Description string `json:"description"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(doc.Types))
+	for name := range doc.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]string, len(names))
+	consts := make([]constant, 0, len(names))
+	for _, name := range names {
+		c := constant{
+			Name:        "Type" + constName(name),
+			Value:       name,
+			Description: firstSentence(doc.Types[name].Description),
+		}
+		if other, ok := seen[c.Name]; ok {
+			return nil, fmt.Errorf("types %q and %q both map to %s", other, name, c.Name)
+		}
+		seen[c.Name] = name
+		consts = append(consts, c)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, consts); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// firstSentence returns the first sentence of s without its final period.
+func firstSentence(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSuffix(s, ".")
+}
+
+// constName converts a type name to the suffix of its constant name.
+func constName(name string) string {
+	if s, ok := initialisms[name]; ok {
+		return s
+	}
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by gentypes from types.json; DO NOT EDIT.
+
+package purl
+
+// Type is a PURL type name. Constants are provided for every type in the
+// embedded types.json, so a type removed or renamed there fails to compile
+// rather than silently missing at runtime.
+type Type string
+
+// PURL types defined in the embedded types.json.
+const (
+{{- range .}}
+	// {{.Name}} is the {{printf "%q" .Value}} type{{with .Description}}: {{.}}{{end}}.
+	{{.Name}} Type = {{printf "%q" .Value}}
+{{- end}}
+)
+
+// String returns the type name.
+func (t Type) String() string {
+	return string(t)
+}
+
+// Config returns the type's configuration from the default registry, or nil
+// if the type is not defined there.
+func (t Type) Config() *TypeConfig {
+	return TypeInfo(string(t))
+}
+`))
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../types.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := render(data)
+	if err != nil {
+		t.Fatalf("render() error: %v", err)
+	}
+	got, err := os.ReadFile("../../typeconsts.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("typeconsts.go is out of date; run go generate")
+	}
+}
+
+func TestConstName(t *testing.T) {
+	tests := map[string]string{
+		"npm":         "NPM",
+		"maven":       "Maven",
+		"huggingface": "Huggingface",
+		"github":      "GitHub",
+		"vscode-ext":  "VscodeExt",
+		"foo.bar":     "FooBar",
+	}
+	for in, want := range tests {
+		if got := constName(in); got != want {
+			t.Errorf("constName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrUnknownRegistryLayout is returned when a PURL's repository_url points at
+// a registry whose URL layout is not known, so no URL can be built for it.
+var ErrUnknownRegistryLayout = errors.New("unknown registry layout for repository_url")
+
+// RegistryLayoutError reports that no URL could be built against a PURL's
+// repository_url. It unwraps to ErrUnknownRegistryLayout.
+type RegistryLayoutError struct {
+	Type          string // PURL type
+	RepositoryURL string // the repository_url qualifier
+	Layout        string // detected registry product, empty if none matched
+	Kind          string // "registry", "API" or "download"
+}
+
+func (e *RegistryLayoutError) Error() string {
+	if e.Layout == "" {
+		return fmt.Sprintf("%v: no known %s layout for %q", ErrUnknownRegistryLayout, e.Type, e.RepositoryURL)
+	}
+	return fmt.Sprintf("%v: %s layout at %q has no %s URL for %s packages",
+		ErrUnknownRegistryLayout, e.Layout, e.RepositoryURL, e.Kind, e.Type)
+}
+
+func (e *RegistryLayoutError) Unwrap() error {
+	return ErrUnknownRegistryLayout
+}
+
+// registryLayout describes how a registry product arranges its URLs. The
+// pattern is matched against repository_url, and its named groups, such as
+// {base} and {repo}, are available to the templates along with the usual
+// PURL placeholders.
+type registryLayout struct {
+	name    string
+	pattern *regexp.Regexp
+	types   map[Type]layoutTemplates
+}
+
+// layoutTemplates are the URL templates a layout offers for one type. Empty
+// templates mean the product has no such URL for the type.
+type layoutTemplates struct {
+	registry            string
+	registryWithVersion string
+	api                 string
+	download            string
+}
+
+// Templates shared by products that serve the npm and Maven protocols
+// under a repository path.
+const (
+	npmAPIPath        = "/{namespace}/{name}"
+	npmDownloadPath   = "/{namespace}/{name}/-/{name}-{version}.tgz"
+	mavenPackagePath  = "/{namespace_path}/{name}/"
+	mavenVersionPath  = "/{namespace_path}/{name}/{version}/"
+	mavenMetadataPath = "/{namespace_path}/{name}/maven-metadata.xml"
+	mavenDownloadPath = "/{namespace_path}/{name}/{version}/{name}-{version}{-qualifier.classifier}.{qualifier.type}"
+	gemDownloadPath   = "/gems/{name}-{version}{-qualifier.platform}.gem"
+	pypiSimplePath    = "/simple/{name}/"
+)
+
+// registryLayouts are tried in order against repository_url. The generic
+// Maven layout comes last: any Maven repository uses the maven2 layout.
+var registryLayouts = []registryLayout{
+	{
+		name:    "GitHub Packages",
+		pattern: regexp.MustCompile(`^https://(?:npm|maven|nuget|rubygems)\.pkg\.github\.com(?:/(?P<owner>[^/]+)(?:/(?P<repo>[^/]+))?)?`),
+		types: map[Type]layoutTemplates{
+			TypeNPM: {api: "https://npm.pkg.github.com" + npmAPIPath},
+			TypeMaven: {
+				api:      "https://maven.pkg.github.com/{owner}/{repo}" + mavenMetadataPath,
+				download: "https://maven.pkg.github.com/{owner}/{repo}" + mavenDownloadPath,
+			},
+			TypeNuGet: {
+				api:      "https://nuget.pkg.github.com/{owner}/download/{name_lower}/index.json",
+				download: "https://nuget.pkg.github.com/{owner}/download/{name_lower}/{version_lower}/{name_lower}.{version_lower}.nupkg",
+			},
+			TypeGem: {download: "https://rubygems.pkg.github.com/{owner}" + gemDownloadPath},
+		},
+	},
+	{
+		name:    "GitLab",
+		pattern: regexp.MustCompile(`^(?P<base>https?://[^/]+(?:/[^/]+)*?)/api/v4/(?P<scope>projects|groups)/(?P<project>[^/]+)/packages/`),
+		types: map[Type]layoutTemplates{
+			TypeNPM: {
+				api:      "{base}/api/v4/{scope}/{project}/packages/npm" + npmAPIPath,
+				download: "{base}/api/v4/{scope}/{project}/packages/npm" + npmDownloadPath,
+			},
+			TypeMaven: {
+				api:      "{base}/api/v4/{scope}/{project}/packages/maven" + mavenMetadataPath,
+				download: "{base}/api/v4/{scope}/{project}/packages/maven" + mavenDownloadPath,
+			},
+			TypePyPI: {api: "{base}/api/v4/{scope}/{project}/packages/pypi" + pypiSimplePath},
+		},
+	},
+	{
+		name:    "Artifactory",
+		pattern: regexp.MustCompile(`^(?P<base>https?://[^/]+(?:/[^/]+)*?/artifactory)/(?:api/[^/]+/)?(?P<repo>[^/]+)`),
+		types: map[Type]layoutTemplates{
+			TypeNPM: {
+				api:      "{base}/api/npm/{repo}" + npmAPIPath,
+				download: "{base}/api/npm/{repo}" + npmDownloadPath,
+			},
+			TypeMaven: {
+				registry:            "{base}/list/{repo}" + mavenPackagePath,
+				registryWithVersion: "{base}/list/{repo}" + mavenVersionPath,
+				api:                 "{base}/{repo}" + mavenMetadataPath,
+				download:            "{base}/{repo}" + mavenDownloadPath,
+			},
+			TypePyPI: {api: "{base}/api/pypi/{repo}" + pypiSimplePath},
+			TypeGem: {
+				api:      "{base}/api/gems/{repo}/api/v1/gems/{name}.json",
+				download: "{base}/api/gems/{repo}" + gemDownloadPath,
+			},
+		},
+	},
+	{
+		name:    "Nexus",
+		pattern: regexp.MustCompile(`^(?P<base>https?://[^/]+(?:/[^/]+)*?)/repository/(?P<repo>[^/]+)`),
+		types: map[Type]layoutTemplates{
+			TypeNPM: {
+				registry: "{base}/service/rest/repository/browse/{repo}" + npmAPIPath + "/",
+				api:      "{base}/repository/{repo}" + npmAPIPath,
+				download: "{base}/repository/{repo}" + npmDownloadPath,
+			},
+			TypeMaven: {
+				registry:            "{base}/service/rest/repository/browse/{repo}" + mavenPackagePath,
+				registryWithVersion: "{base}/service/rest/repository/browse/{repo}" + mavenVersionPath,
+				api:                 "{base}/repository/{repo}" + mavenMetadataPath,
+				download:            "{base}/repository/{repo}" + mavenDownloadPath,
+			},
+			TypePyPI: {api: "{base}/repository/{repo}" + pypiSimplePath},
+			TypeGem:  {download: "{base}/repository/{repo}" + gemDownloadPath},
+		},
+	},
+	{
+		name:    "devpi",
+		pattern: regexp.MustCompile(`^(?P<base>https?://[^/]+)/(?P<user>[^/+]+)/(?P<index>[^/+]+)/\+simple(?:/|$)`),
+		types: map[Type]layoutTemplates{
+			TypePyPI: {
+				registry:            "{base}/{user}/{index}/{name}",
+				registryWithVersion: "{base}/{user}/{index}/{name}/{version}",
+				api:                 "{base}/{user}/{index}/+simple/{name}/",
+			},
+		},
+	},
+	{
+		name:    "Verdaccio",
+		pattern: regexp.MustCompile(`^(?P<base>https?://(?:[^/]*verdaccio[^/]*|[^/]+:4873))(?:/|$)`),
+		types: map[Type]layoutTemplates{
+			TypeNPM: {
+				registry: "{base}/-/web/detail" + npmAPIPath,
+				api:      "{base}" + npmAPIPath,
+				download: "{base}" + npmDownloadPath,
+			},
+		},
+	},
+	{
+		name:    "Maven",
+		pattern: regexp.MustCompile(`^(?P<base>https?://.+?)/*$`),
+		types: map[Type]layoutTemplates{
+			TypeMaven: {
+				registry:            "{base}" + mavenPackagePath,
+				registryWithVersion: "{base}" + mavenVersionPath,
+				api:                 "{base}" + mavenMetadataPath,
+				download:            "{base}" + mavenDownloadPath,
+			},
+		},
+	},
+}
+
+// layoutPlaceholder matches the repository_url placeholders of a layout
+// template, which unlike the PURL placeholders are copied from
+// repository_url as written.
+var layoutPlaceholder = regexp.MustCompile(`\{(base|repo|owner|scope|project|user|index)\}`)
+
+// privateRegistry returns the repository_url a URL should be built against,
+// or "" when the PURL has none or it is the type's default registry.
+func (r *Registry) privateRegistry(p *PURL) string {
+	repoURL := p.RepositoryURL()
+	if !r.IsNonDefaultRegistry(p.Type, repoURL) {
+		return ""
+	}
+	return repoURL
+}
+
+// layoutURL builds a URL of the given kind against repoURL. pick selects
+// the template from the matching layout's templates for the type.
+func layoutURL(p *PURL, rc *RegistryConfig, repoURL, kind string, pick func(layoutTemplates) string) (string, error) {
+	layout, vars := findLayout(Type(p.Type), repoURL)
+	if layout == nil {
+		return "", &RegistryLayoutError{Type: p.Type, RepositoryURL: repoURL}
+	}
+	layoutErr := &RegistryLayoutError{Type: p.Type, RepositoryURL: repoURL, Layout: layout.name, Kind: kind}
+
+	template := pick(layout.types[Type(p.Type)])
+	if template == "" {
+		return "", layoutErr
+	}
+
+	for _, m := range layoutPlaceholder.FindAllStringSubmatch(template, -1) {
+		if vars[m[1]] == "" {
+			return "", layoutErr
+		}
+	}
+	if p.Namespace == "" {
+		template = strings.ReplaceAll(template, "/{namespace}/", "/")
+	}
+
+	// The PURL placeholders are filled first: their values are escaped, so
+	// they can't contain braces, while repository_url parts are inserted
+	// as written and must not be expanded again.
+	template = fillQualifiers(template, p.Qualifiers.Map(), rc.DownloadQualifierDefaults)
+	result := fillTemplate(template, &rc.Components, p.Namespace, p.Name, p.Version)
+	return layoutPlaceholder.ReplaceAllStringFunc(result, func(m string) string {
+		return vars[m[1:len(m)-1]]
+	}), nil
+}
+
+// findLayout returns the first layout that matches repoURL and has
+// templates for purlType, along with the pattern's named groups. The groups
+// are kept as they appear in repoURL, escapes included, since they are
+// copied into URLs rather than parsed.
+func findLayout(purlType Type, repoURL string) (*registryLayout, map[string]string) {
+	for i := range registryLayouts {
+		layout := &registryLayouts[i]
+		if _, ok := layout.types[purlType]; !ok {
+			continue
+		}
+		matches := layout.pattern.FindStringSubmatch(repoURL)
+		if matches == nil {
+			continue
+		}
+		vars := make(map[string]string)
+		for i, group := range layout.pattern.SubexpNames() {
+			if group != "" {
+				vars[group] = matches[i]
+			}
+		}
+		return layout, vars
+	}
+	return nil, nil
+}
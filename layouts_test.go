@@ -0,0 +1,213 @@
+package purl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPrivateRegistryURLs(t *testing.T) {
+	tests := []struct {
+		purl     string
+		registry string
+		api      string
+		download string
+	}{
+		{
+			"pkg:npm/%40acme/widgets@1.2.0?repository_url=https://acme.jfrog.io/artifactory/api/npm/npm-local/",
+			"",
+			"https://acme.jfrog.io/artifactory/api/npm/npm-local/@acme/widgets",
+			"https://acme.jfrog.io/artifactory/api/npm/npm-local/@acme/widgets/-/widgets-1.2.0.tgz",
+		},
+		{
+			"pkg:maven/com.acme/widgets@1.2.0?classifier=sources&repository_url=https://acme.jfrog.io/artifactory/libs-release",
+			"https://acme.jfrog.io/artifactory/list/libs-release/com/acme/widgets/1.2.0/",
+			"https://acme.jfrog.io/artifactory/libs-release/com/acme/widgets/maven-metadata.xml",
+			"https://acme.jfrog.io/artifactory/libs-release/com/acme/widgets/1.2.0/widgets-1.2.0-sources.jar",
+		},
+		{
+			"pkg:pypi/widgets@1.2.0?repository_url=https://acme.jfrog.io/artifactory/api/pypi/pypi-local/simple",
+			"",
+			"https://acme.jfrog.io/artifactory/api/pypi/pypi-local/simple/widgets/",
+			"",
+		},
+		{
+			"pkg:npm/widgets@1.2.0?repository_url=https://nexus.acme.com/repository/npm-proxy/",
+			"https://nexus.acme.com/service/rest/repository/browse/npm-proxy/widgets/",
+			"https://nexus.acme.com/repository/npm-proxy/widgets",
+			"https://nexus.acme.com/repository/npm-proxy/widgets/-/widgets-1.2.0.tgz",
+		},
+		{
+			"pkg:maven/com.acme/widgets@1.2.0?type=pom&repository_url=https://nexus.acme.com/repository/maven-releases",
+			"https://nexus.acme.com/service/rest/repository/browse/maven-releases/com/acme/widgets/1.2.0/",
+			"https://nexus.acme.com/repository/maven-releases/com/acme/widgets/maven-metadata.xml",
+			"https://nexus.acme.com/repository/maven-releases/com/acme/widgets/1.2.0/widgets-1.2.0.pom",
+		},
+		{
+			"pkg:npm/%40acme/widgets@1.2.0?repository_url=https://npm.pkg.github.com",
+			"",
+			"https://npm.pkg.github.com/@acme/widgets",
+			"",
+		},
+		{
+			"pkg:maven/com.acme/widgets@1.2.0?repository_url=https://maven.pkg.github.com/acme/widgets",
+			"",
+			"https://maven.pkg.github.com/acme/widgets/com/acme/widgets/maven-metadata.xml",
+			"https://maven.pkg.github.com/acme/widgets/com/acme/widgets/1.2.0/widgets-1.2.0.jar",
+		},
+		{
+			"pkg:nuget/Acme.Widgets@1.2.0?repository_url=https://nuget.pkg.github.com/acme/index.json",
+			"",
+			"https://nuget.pkg.github.com/acme/download/acme.widgets/index.json",
+			"https://nuget.pkg.github.com/acme/download/acme.widgets/1.2.0/acme.widgets.1.2.0.nupkg",
+		},
+		{
+			"pkg:gem/widgets@1.2.0?repository_url=https://rubygems.pkg.github.com/acme",
+			"",
+			"",
+			"https://rubygems.pkg.github.com/acme/gems/widgets-1.2.0.gem",
+		},
+		{
+			"pkg:npm/%40acme/widgets@1.2.0?repository_url=https://gitlab.example.com/api/v4/projects/42/packages/npm/",
+			"",
+			"https://gitlab.example.com/api/v4/projects/42/packages/npm/@acme/widgets",
+			"https://gitlab.example.com/api/v4/projects/42/packages/npm/@acme/widgets/-/widgets-1.2.0.tgz",
+		},
+		{
+			"pkg:pypi/widgets@1.2.0?repository_url=https://gitlab.example.com/api/v4/groups/7/packages/pypi/simple",
+			"",
+			"https://gitlab.example.com/api/v4/groups/7/packages/pypi/simple/widgets/",
+			"",
+		},
+		{
+			"pkg:npm/widgets@1.2.0?repository_url=http://localhost:4873/",
+			"http://localhost:4873/-/web/detail/widgets",
+			"http://localhost:4873/widgets",
+			"http://localhost:4873/widgets/-/widgets-1.2.0.tgz",
+		},
+		{
+			"pkg:pypi/widgets@1.2.0?repository_url=https://devpi.acme.com/root/prod/+simple/",
+			"https://devpi.acme.com/root/prod/widgets/1.2.0",
+			"https://devpi.acme.com/root/prod/+simple/widgets/",
+			"",
+		},
+		{
+			"pkg:maven/com.acme/widgets@1.2.0?repository_url=https://repo.acme.com/maven2/",
+			"https://repo.acme.com/maven2/com/acme/widgets/1.2.0/",
+			"https://repo.acme.com/maven2/com/acme/widgets/maven-metadata.xml",
+			"https://repo.acme.com/maven2/com/acme/widgets/1.2.0/widgets-1.2.0.jar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			urls := []struct {
+				kind string
+				fn   func() (string, error)
+				want string
+			}{
+				{"RegistryURLWithVersion", p.RegistryURLWithVersion, tt.registry},
+				{"RegistryAPIURL", p.RegistryAPIURL, tt.api},
+				{"DownloadURL", p.DownloadURL, tt.download},
+			}
+			for _, u := range urls {
+				got, err := u.fn()
+				if u.want == "" {
+					if !errors.Is(err, ErrUnknownRegistryLayout) {
+						t.Errorf("%s() = %q, %v, want ErrUnknownRegistryLayout", u.kind, got, err)
+					}
+					continue
+				}
+				if err != nil || got != u.want {
+					t.Errorf("%s() = %q, %v, want %q", u.kind, got, err, u.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrivateRegistryURLWithoutVersion(t *testing.T) {
+	p, _ := Parse("pkg:npm/%40acme/widgets?repository_url=http://verdaccio.acme.internal")
+	got, err := p.RegistryURL()
+	if err != nil || got != "http://verdaccio.acme.internal/-/web/detail/@acme/widgets" {
+		t.Errorf("RegistryURL() = %q, %v", got, err)
+	}
+}
+
+func TestUnknownRegistryLayout(t *testing.T) {
+	p, _ := Parse("pkg:npm/lodash@4.17.21?repository_url=https://npm.example.com")
+
+	_, err := p.RegistryURL()
+	var layoutErr *RegistryLayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("RegistryURL() error = %v, want *RegistryLayoutError", err)
+	}
+	if layoutErr.Type != "npm" || layoutErr.RepositoryURL != "https://npm.example.com" || layoutErr.Layout != "" {
+		t.Errorf("RegistryLayoutError = %+v", layoutErr)
+	}
+	want := `unknown registry layout for repository_url: no known npm layout for "https://npm.example.com"`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	for _, fn := range []func() (string, error){p.RegistryAPIURL, p.DownloadURL} {
+		if _, err := fn(); !errors.Is(err, ErrUnknownRegistryLayout) {
+			t.Errorf("error = %v, want ErrUnknownRegistryLayout", err)
+		}
+	}
+}
+
+func TestDefaultRepositoryURLUsesPublicRegistry(t *testing.T) {
+	p, _ := Parse("pkg:npm/lodash@4.17.21?repository_url=https://registry.npmjs.org")
+	got, err := p.DownloadURL()
+	if err != nil || got != "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz" {
+		t.Errorf("DownloadURL() = %q, %v", got, err)
+	}
+}
+
+func TestLayoutTemplatePlaceholders(t *testing.T) {
+	for _, layout := range registryLayouts {
+		for typ, tmpl := range layout.types {
+			for _, s := range []string{tmpl.registry, tmpl.registryWithVersion, tmpl.api, tmpl.download} {
+				for _, m := range templatePlaceholder.FindAllStringSubmatch(s, -1) {
+					if !templatePlaceholders[m[1]] && qualifierPlaceholderKey(m[1]) == "" && !layoutPlaceholder.MatchString(m[0]) {
+						t.Errorf("%s %s: unknown placeholder %s in %q", layout.name, typ, m[0], s)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLayoutRepositoryURLCopiedAsWritten(t *testing.T) {
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{
+			"pkg:npm/lodash@1.0.0?repository_url=https://x.example/repository/npm%2520proxy",
+			"https://x.example/repository/npm%20proxy/lodash/-/lodash-1.0.0.tgz",
+		},
+		{
+			"pkg:npm/lodash@1.0.0?repository_url=https://x.example/repository/%257Bversion%257D",
+			"https://x.example/repository/%7Bversion%7D/lodash/-/lodash-1.0.0.tgz",
+		},
+		{
+			"pkg:npm/lodash@1.0.0?repository_url=https://x.example/repository/{version}",
+			"https://x.example/repository/{version}/lodash/-/lodash-1.0.0.tgz",
+		},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.purl)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.purl, err)
+		}
+		got, err := p.DownloadURL()
+		if err != nil || got != tt.want {
+			t.Errorf("DownloadURL(%s) = %q, %v, want %q", tt.purl, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package purl
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// FixKind identifies a repair applied by ParseLenient.
+type FixKind string
+
+// Repairs ParseLenient can apply, in the order they are attempted.
+const (
+	// FixWhitespace trims surrounding whitespace, drops whitespace next to
+	// delimiters and percent-encodes any whitespace left inside components.
+	FixWhitespace FixKind = "whitespace"
+	// FixScheme adds a missing "pkg:" prefix or lowercases an uppercase one.
+	FixScheme FixKind = "scheme"
+	// FixSlashes collapses doubled slashes and drops trailing slashes in the
+	// namespace/name path and after the scheme.
+	FixSlashes FixKind = "slashes"
+	// FixTypeCase lowercases the type.
+	FixTypeCase FixKind = "type_case"
+	// FixScopeEncoding percent-encodes the leading "@" of an npm scope.
+	FixScopeEncoding FixKind = "scope_encoding"
+	// FixPercentEncoding encodes a "%" that does not start a valid escape.
+	FixPercentEncoding FixKind = "percent_encoding"
+	// FixNameCase records that the type's normalization rules rewrote the
+	// namespace or name, such as lowercasing a mixed-case golang module path.
+	FixNameCase FixKind = "name_case"
+)
+
+// Fix records one repair made by ParseLenient.
+type Fix struct {
+	Kind   FixKind
+	Before string
+	After  string
+}
+
+// ParseLenient parses s like Parse, first repairing common problems found in
+// PURLs emitted by third-party tools. Each repair that changed the input is
+// returned as a Fix, in the order applied, so callers can audit what was
+// rewritten. The repairs are, in order:
+//
+//   - FixWhitespace: "  pkg:npm/ lodash @4.17.21 " -> "pkg:npm/lodash@4.17.21"
+//   - FixScheme: "npm/lodash" -> "pkg:npm/lodash", "PKG:npm/lodash" -> "pkg:npm/lodash"
+//   - FixSlashes: "pkg:maven/org.apache//commons-lang3/" -> "pkg:maven/org.apache/commons-lang3"
+//   - FixTypeCase: "pkg:NPM/lodash" -> "pkg:npm/lodash"
+//   - FixScopeEncoding: "pkg:npm/@babel/core@7" -> "pkg:npm/%40babel/core@7"
+//   - FixPercentEncoding: "pkg:generic/100%" -> "pkg:generic/100%25"
+//   - FixNameCase: "pkg:golang/GitHub.com/Foo/Bar" parses as "pkg:golang/github.com/foo/bar"
+//
+// If the repaired string still fails to parse, the fixes made so far are
+// returned along with the Parse error. Empty or blank input is not repaired;
+// its Parse error is returned with no fixes.
+func ParseLenient(s string) (*PURL, []Fix, error) {
+	if strings.TrimSpace(s) == "" {
+		_, err := Parse(s)
+		return nil, nil, err
+	}
+
+	var fixes []Fix
+	apply := func(kind FixKind, repair func(string) string) {
+		if after := repair(s); after != s {
+			fixes = append(fixes, Fix{Kind: kind, Before: s, After: after})
+			s = after
+		}
+	}
+
+	apply(FixWhitespace, repairWhitespace)
+	apply(FixScheme, repairScheme)
+	apply(FixSlashes, repairSlashes)
+	apply(FixTypeCase, repairTypeCase)
+	apply(FixScopeEncoding, repairScopeEncoding)
+	apply(FixPercentEncoding, repairPercentEncoding)
+
+	p, err := Parse(s)
+	if err != nil {
+		return nil, fixes, err
+	}
+
+	if ns, name, ok := rawNamespaceName(s); ok && (ns != p.Namespace || name != p.Name) {
+		fixes = append(fixes, Fix{Kind: FixNameCase, Before: s, After: p.String()})
+	}
+
+	return p, fixes, nil
+}
+
+// splitLenient splits a string beginning with "pkg:" into its type, the
+// namespace/name/version path, and the qualifiers and subpath ("?...#...").
+// It reports false if s has no type separator.
+func splitLenient(s string) (typ, path, tail string, ok bool) {
+	rest := strings.TrimLeft(strings.TrimPrefix(s, "pkg:"), "/")
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest, tail = rest[:i], rest[i:]
+	}
+	typ, path, ok = strings.Cut(rest, "/")
+	return typ, path, tail, ok
+}
+
+// isLenientDelimiter reports whether c separates PURL components, so
+// whitespace next to it can be dropped.
+func isLenientDelimiter(c byte) bool {
+	return strings.IndexByte(":/@?&=#", c) >= 0
+}
+
+func repairWhitespace(s string) string {
+	s = strings.TrimSpace(s)
+	var b strings.Builder
+	for i, r := range s {
+		if !unicode.IsSpace(r) {
+			b.WriteRune(r)
+			continue
+		}
+		prev := strings.TrimRightFunc(s[:i], unicode.IsSpace)
+		next := strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+		if isLenientDelimiter(prev[len(prev)-1]) || isLenientDelimiter(next[0]) {
+			continue
+		}
+		b.WriteString(url.PathEscape(string(r)))
+	}
+	return b.String()
+}
+
+func repairScheme(s string) string {
+	switch {
+	case strings.HasPrefix(s, "pkg:"):
+		return s
+	case len(s) >= 4 && strings.EqualFold(s[:4], "pkg:"):
+		return "pkg:" + s[4:]
+	}
+	return "pkg:" + s
+}
+
+func repairSlashes(s string) string {
+	typ, path, tail, ok := splitLenient(s)
+	if !ok {
+		return s
+	}
+	version := ""
+	if i := strings.LastIndexByte(path, '@'); i > 0 {
+		path, version = path[:i], path[i:]
+	}
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	return "pkg:" + typ + "/" + strings.Join(segments, "/") + version + tail
+}
+
+func repairTypeCase(s string) string {
+	typ, path, tail, ok := splitLenient(s)
+	if !ok || typ == strings.ToLower(typ) {
+		return s
+	}
+	return "pkg:" + strings.ToLower(typ) + "/" + path + tail
+}
+
+func repairScopeEncoding(s string) string {
+	typ, path, tail, ok := splitLenient(s)
+	if !ok || Type(typ) != TypeNPM || !strings.HasPrefix(path, "@") || !strings.Contains(path, "/") {
+		return s
+	}
+	return "pkg:" + typ + "/%40" + path[1:] + tail
+}
+
+func repairPercentEncoding(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && invalidEscapeIndex(s[i:]) == 0 {
+			b.WriteString("%25")
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// rawNamespaceName splits and decodes the namespace and name of s without
+// applying any type-specific normalization.
+func rawNamespaceName(s string) (namespace, name string, ok bool) {
+	_, path, _, _ := splitLenient(s)
+	if i := strings.LastIndexByte(path, '@'); i > 0 {
+		path = path[:i]
+	}
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		namespace, path = path[:i], path[i+1:]
+	}
+	name, err := url.PathUnescape(path)
+	if err != nil {
+		return "", "", false
+	}
+	namespace, err = url.PathUnescape(namespace)
+	if err != nil {
+		return "", "", false
+	}
+	return namespace, name, true
+}
@@ -0,0 +1,78 @@
+package purl
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      string
+		wantFixes []FixKind
+	}{
+		// Clean input needs no fixes
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash@4.17.21", nil},
+		{"pkg:npm/%40babel/core@7.24.0", "pkg:npm/%40babel/core@7.24.0", nil},
+
+		{"  pkg:npm/ lodash @4.17.21 ", "pkg:npm/lodash@4.17.21", []FixKind{FixWhitespace}},
+		{"pkg:generic/my tool@1.0", "pkg:generic/my%20tool@1.0", []FixKind{FixWhitespace}},
+		{"npm/lodash", "pkg:npm/lodash", []FixKind{FixScheme}},
+		{"PKG:npm/lodash", "pkg:npm/lodash", []FixKind{FixScheme}},
+		{"pkg:NPM/lodash", "pkg:npm/lodash", []FixKind{FixTypeCase}},
+		{"pkg://cargo/serde", "pkg:cargo/serde", []FixKind{FixSlashes}},
+		{"pkg:maven/org.apache//commons-lang3/@3.12.0", "pkg:maven/org.apache/commons-lang3@3.12.0", []FixKind{FixSlashes}},
+		{"pkg:npm/@babel/core@7", "pkg:npm/%40babel/core@7", []FixKind{FixScopeEncoding}},
+		{"pkg:generic/100%@1", "pkg:generic/100%25@1", []FixKind{FixPercentEncoding}},
+		{"pkg:golang/GitHub.com/Gorilla/Mux@v1.8.0", "pkg:golang/github.com/gorilla/mux@v1.8.0", []FixKind{FixNameCase}},
+		{"pkg:pypi/Django_REST", "pkg:pypi/django-rest", []FixKind{FixNameCase}},
+
+		// Several repairs in order
+		{" NPM/@babel//core ", "pkg:npm/%40babel/core", []FixKind{FixWhitespace, FixScheme, FixSlashes, FixTypeCase, FixScopeEncoding}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p, fixes, err := ParseLenient(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLenient(%q) error: %v", tt.input, err)
+			}
+			if got := p.String(); got != tt.want {
+				t.Errorf("ParseLenient(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			var kinds []FixKind
+			for _, f := range fixes {
+				kinds = append(kinds, f.Kind)
+				if f.Before == f.After {
+					t.Errorf("fix %q did not change input %q", f.Kind, f.Before)
+				}
+			}
+			if !reflect.DeepEqual(kinds, tt.wantFixes) {
+				t.Errorf("fixes = %v, want %v", kinds, tt.wantFixes)
+			}
+		})
+	}
+}
+
+func TestParseLenientError(t *testing.T) {
+	_, fixes, err := ParseLenient(" pkg:cargo ")
+	if err == nil {
+		t.Fatal("ParseLenient() error = nil, want error")
+	}
+	if len(fixes) != 1 || fixes[0].Kind != FixWhitespace {
+		t.Errorf("fixes = %v, want one whitespace fix", fixes)
+	}
+}
+
+func TestParseLenientEmpty(t *testing.T) {
+	for _, s := range []string{"", "   ", "\t\n"} {
+		_, fixes, err := ParseLenient(s)
+		if !errors.Is(err, ErrInvalidScheme) {
+			t.Errorf("ParseLenient(%q) error = %v, want ErrInvalidScheme", s, err)
+		}
+		if fixes != nil {
+			t.Errorf("ParseLenient(%q) fixes = %v, want none", s, fixes)
+		}
+	}
+}
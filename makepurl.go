@@ -9,13 +9,14 @@ import (
 
 // CleanVersion extracts a version from a version constraint string.
 // Uses the vers library to parse the constraint and extract the minimum bound.
-// If parsing fails, returns the original string.
-func CleanVersion(version, scheme string) string {
+// The constraint is read in the vers scheme of purlType, as returned by
+// VersionSchemeFor. If parsing fails, returns the original string.
+func CleanVersion(version, purlType string) string {
 	if version == "" {
 		return ""
 	}
 
-	r, err := vers.ParseNative(version, scheme)
+	r, err := vers.ParseNative(version, VersionSchemeFor(purlType))
 	if err != nil || len(r.Intervals) == 0 {
 		return version
 	}
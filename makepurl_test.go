@@ -28,6 +28,10 @@ func TestCleanVersion(t *testing.T) {
 		// cargo constraints
 		{"^1.0.0", "cargo", "1.0.0"},
 
+		// PURL types whose vers scheme has a different name
+		{">=3.1.4-r0", "apk", "3.1.4-r0"},
+		{">=6.0.1-1", "alpm", "6.0.1-1"},
+
 		// Plain versions pass through
 		{"1.0.0", "npm", "1.0.0"},
 		{"v1.0.0", "go", "v1.0.0"},
@@ -0,0 +1,216 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// ErrInvalidScheme is returned when a PURL does not start with "pkg:".
+var ErrInvalidScheme = errors.New(`purl scheme is not "pkg"`)
+
+// ErrMissingType is returned when a PURL has no type.
+var ErrMissingType = errors.New("purl is missing type")
+
+// ErrInvalidEscape is returned when a component contains a malformed percent-encoding.
+var ErrInvalidEscape = errors.New("invalid percent-encoding")
+
+// ErrInvalidQualifier is returned when a qualifier key is invalid or repeated.
+var ErrInvalidQualifier = errors.New("invalid qualifier")
+
+// ErrInvalidSubpath is returned when a subpath contains "." or ".." segments.
+var ErrInvalidSubpath = errors.New("invalid subpath")
+
+// ErrTypeRule is returned when a PURL breaks a type-specific rule enforced by
+// packageurl-go, such as cpan requiring an uppercase namespace.
+var ErrTypeRule = errors.New("purl violates a type-specific rule")
+
+// ParseError describes why Parse rejected its input.
+// It unwraps to one of the Err* sentinel errors so callers can use errors.Is.
+type ParseError struct {
+	Input     string // the string passed to Parse
+	Component string // one of the Component* constants
+	Offset    int    // byte offset into Input where the problem starts
+	Err       error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse purl %q: %s at offset %d: %v", e.Input, e.Component, e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError locates the component of s that packageurl-go rejected.
+// It walks s the same way packageurl.FromString does, recording offsets, and
+// falls back to wrapping cause in ErrTypeRule when the structure is sound but
+// a type-specific rule failed. Such a failure is blamed on the namespace when
+// it breaks the type's namespace requirement in r, and on the type otherwise.
+func (r *Registry) newParseError(s string, cause error) *ParseError {
+	perr := func(component string, offset int, err error) *ParseError {
+		return &ParseError{Input: s, Component: component, Offset: offset, Err: err}
+	}
+
+	if len(s) < 4 || !strings.EqualFold(s[:4], "pkg:") {
+		return perr(ComponentScheme, 0, ErrInvalidScheme)
+	}
+
+	start := 4
+	for start < len(s) && s[start] == '/' {
+		start++
+	}
+	end := len(s)
+
+	subpathStart := -1
+	if i := strings.IndexByte(s[start:end], '#'); i >= 0 {
+		subpathStart = start + i + 1
+		end = start + i
+	}
+	qualifiersStart := -1
+	qualifiersEnd := end
+	if i := strings.IndexByte(s[start:end], '?'); i >= 0 {
+		qualifiersStart = start + i + 1
+		end = start + i
+	}
+
+	slash := strings.IndexByte(s[start:end], '/')
+	if slash < 0 {
+		if start == end {
+			return perr(ComponentType, start, ErrMissingType)
+		}
+		return perr(ComponentName, end, ErrEmptyName)
+	}
+	typ := s[start : start+slash]
+	if typ == "" {
+		return perr(ComponentType, start, ErrMissingType)
+	}
+	if !packageurl.TypePattern.MatchString(typ) {
+		return perr(ComponentType, start+invalidTypeIndex(typ), ErrInvalidType)
+	}
+	typ = strings.ToLower(typ)
+	pathStart := start + slash + 1
+
+	if qualifiersStart >= 0 {
+		if err := checkQualifiers(s, qualifiersStart, qualifiersEnd); err != nil {
+			return err
+		}
+	}
+
+	pathEnd := end
+	path := s[pathStart:pathEnd]
+	if Type(typ) != TypeNPM && strings.HasPrefix(path, "@") {
+		return perr(ComponentName, pathStart, ErrEmptyName)
+	}
+	if i := strings.LastIndexByte(path, '@'); i > 0 {
+		versionStart := pathStart + i + 1
+		end = pathStart + i
+		if off := invalidEscapeIndex(s[versionStart:pathEnd]); off >= 0 {
+			return perr(ComponentVersion, versionStart+off, ErrInvalidEscape)
+		}
+	}
+	nameStart := pathStart
+	if i := strings.LastIndexByte(s[pathStart:end], '/'); i >= 0 {
+		nameStart = pathStart + i + 1
+	}
+	if off := invalidEscapeIndex(s[nameStart:end]); off >= 0 {
+		return perr(ComponentName, nameStart+off, ErrInvalidEscape)
+	}
+	if nameStart > pathStart {
+		if off := invalidEscapeIndex(s[pathStart:nameStart]); off >= 0 {
+			return perr(ComponentNamespace, pathStart+off, ErrInvalidEscape)
+		}
+	}
+	if nameStart == end {
+		return perr(ComponentName, nameStart, ErrEmptyName)
+	}
+
+	if subpathStart >= 0 {
+		offset := subpathStart
+		for i, seg := range strings.Split(s[subpathStart:], "/") {
+			if (seg == "." || seg == "..") && i != 0 {
+				return perr(ComponentSubpath, offset, ErrInvalidSubpath)
+			}
+			offset += len(seg) + 1
+		}
+	}
+
+	component, offset := ComponentType, start
+	if cfg, ok := r.lookupType(typ); ok {
+		hasNamespace := nameStart > pathStart
+		if (cfg.NamespaceRequired() && !hasNamespace) || (cfg.NamespaceProhibited() && hasNamespace) {
+			component, offset = ComponentNamespace, pathStart
+		}
+	}
+	return perr(component, offset, fmt.Errorf("%w: %v", ErrTypeRule, cause))
+}
+
+// checkQualifiers validates the raw qualifier string s[start:end] with the
+// rules packageurl-go applies, reporting the offset of the first bad pair.
+func checkQualifiers(s string, start, end int) *ParseError {
+	perr := func(offset int, err error) *ParseError {
+		return &ParseError{Input: s, Component: ComponentQualifiers, Offset: offset, Err: err}
+	}
+
+	seen := make(map[string]bool)
+	offset := start
+	for _, pair := range strings.Split(s[start:end], "&") {
+		pairStart := offset
+		offset += len(pair) + 1
+		if pair == "" {
+			continue
+		}
+		if i := strings.IndexByte(pair, ';'); i >= 0 {
+			return perr(pairStart+i, fmt.Errorf("%w: semicolon separator", ErrInvalidQualifier))
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		if !packageurl.QualifierKeyPattern.MatchString(key) {
+			return perr(pairStart, fmt.Errorf("%w: key %q", ErrInvalidQualifier, key))
+		}
+		valueStart := pairStart + len(key) + 1
+		if off := invalidEscapeIndex(value); off >= 0 {
+			return perr(valueStart+off, ErrInvalidEscape)
+		}
+		if value == "" {
+			continue
+		}
+		key = strings.ToLower(key)
+		if seen[key] {
+			return perr(pairStart, fmt.Errorf("%w: duplicate key %q", ErrInvalidQualifier, key))
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// invalidEscapeIndex returns the index of the first malformed percent-encoding
+// in s, or -1 if every '%' is followed by two hex digits.
+func invalidEscapeIndex(s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			continue
+		}
+		if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			return i
+		}
+		i += 2
+	}
+	return -1
+}
+
+// invalidTypeIndex returns the index of the first character in typ that
+// packageurl.TypePattern rejects.
+func invalidTypeIndex(typ string) int {
+	for i := 0; i < len(typ); i++ {
+		if !packageurl.TypePattern.MatchString(typ[:i+1]) {
+			return i
+		}
+	}
+	return 0
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
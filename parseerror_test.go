@@ -0,0 +1,58 @@
+package purl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantErr       error
+		wantComponent string
+		wantOffset    int
+	}{
+		{"cargo/serde", ErrInvalidScheme, ComponentScheme, 0},
+		{"invalid", ErrInvalidScheme, ComponentScheme, 0},
+		{"pkg:", ErrMissingType, ComponentType, 4},
+		{"pkg:/serde", ErrEmptyName, ComponentName, 10},
+		{"pkg:cargo", ErrEmptyName, ComponentName, 9},
+		{"pkg:car_go/serde", ErrInvalidType, ComponentType, 7},
+		{"pkg:cargo/", ErrEmptyName, ComponentName, 10},
+		{"pkg:cargo/@1.0.0", ErrEmptyName, ComponentName, 10},
+		{"pkg:cargo/ser%zzde", ErrInvalidEscape, ComponentName, 13},
+		{"pkg:npm/%4/core", ErrInvalidEscape, ComponentNamespace, 8},
+		{"pkg:npm/lodash@4.%g", ErrInvalidEscape, ComponentVersion, 17},
+		{"pkg:npm/lodash?a=1;b=2", ErrInvalidQualifier, ComponentQualifiers, 18},
+		{"pkg:npm/lodash?a=1&1b=2", ErrInvalidQualifier, ComponentQualifiers, 19},
+		{"pkg:npm/lodash?a=1&A=2", ErrInvalidQualifier, ComponentQualifiers, 19},
+		{"pkg:npm/lodash?a=%x", ErrInvalidEscape, ComponentQualifiers, 17},
+		{"pkg:npm/lodash#foo/../bar", ErrInvalidSubpath, ComponentSubpath, 19},
+		{"pkg:cpan/Moose@2.2014", ErrTypeRule, ComponentNamespace, 9},
+		{"pkg:swift/Alamofire@5.6.4", ErrTypeRule, ComponentNamespace, 10},
+		{"pkg:cpan/ether/Moose@2.2014", ErrTypeRule, ComponentType, 4},
+		{"pkg:cpan/ETHER/Moose::Role", ErrTypeRule, ComponentType, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse(%q) error is %T, want *ParseError", tt.input, err)
+			}
+			if perr.Input != tt.input {
+				t.Errorf("Input = %q, want %q", perr.Input, tt.input)
+			}
+			if perr.Component != tt.wantComponent {
+				t.Errorf("Component = %q, want %q", perr.Component, tt.wantComponent)
+			}
+			if perr.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, want %d", perr.Offset, tt.wantOffset)
+			}
+		})
+	}
+}
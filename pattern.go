@@ -0,0 +1,181 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/git-pkgs/vers"
+)
+
+// ErrInvalidPattern is returned when a pattern string cannot be compiled.
+var ErrInvalidPattern = errors.New("invalid purl pattern")
+
+// Pattern matches PURLs by type, namespace, name, version and qualifiers.
+// Compile one with CompilePattern.
+type Pattern struct {
+	raw        string
+	purlType   string
+	namespace  string
+	name       string
+	version    string
+	versions   *vers.Range
+	qualifiers map[string]string
+}
+
+// CompilePattern compiles a PURL pattern. Patterns look like PURLs whose
+// type, namespace segments, name and version may contain the path.Match
+// wildcards "*" and "[...]", and whose version may instead be a vers range.
+// A "?" always starts the qualifiers, as in a PURL, so it is not a wildcard
+// here; use "[...]" to match a single character:
+//
+//	pkg:npm/@acme/*
+//	pkg:npm/@acme/*@vers:npm/>=1.0.0
+//	pkg:maven/org.apache.logging.log4j/log4j-core@vers:maven/>=2.0.0|<2.17.1
+//	pkg:pypi/django@4.*
+//	pkg:deb/debian/*?arch=amd64
+//
+// The namespace and name are normalized with the type's rules, so
+// pkg:pypi/Django_Rest matches pkg:pypi/django-rest. A pattern with no
+// namespace only matches PURLs with no namespace, and qualifiers in the
+// pattern must be present with the same value on the PURL.
+func CompilePattern(s string) (*Pattern, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidPattern, s, fmt.Sprintf(format, args...))
+	}
+
+	if !strings.HasPrefix(s, "pkg:") {
+		return nil, invalid(`must start with "pkg:"`)
+	}
+	rest := s[len("pkg:"):]
+
+	pat := &Pattern{raw: s}
+
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		for _, pair := range strings.Split(rest[i+1:], "&") {
+			if !strings.Contains(pair, "=") {
+				return nil, invalid(`qualifier %q has no value ("?" starts the qualifiers and is not a wildcard)`, pair)
+			}
+		}
+		q, err := url.ParseQuery(rest[i+1:])
+		if err != nil {
+			return nil, invalid("qualifiers: %v", err)
+		}
+		pat.qualifiers = make(map[string]string, len(q))
+		for k := range q {
+			pat.qualifiers[strings.ToLower(k)] = q.Get(k)
+		}
+		rest = rest[:i]
+	}
+
+	if i := strings.Index(rest, "@vers:"); i >= 0 {
+		r, err := vers.Parse(rest[i+1:])
+		if err != nil {
+			return nil, invalid("version range: %v", err)
+		}
+		pat.versions = r
+		rest = rest[:i]
+	} else if i := strings.LastIndexByte(rest, '@'); i > 0 && rest[i-1] != '/' {
+		v, err := url.PathUnescape(rest[i+1:])
+		if err != nil {
+			return nil, invalid("version: %v", err)
+		}
+		pat.version = v
+		rest = rest[:i]
+	}
+
+	purlType, p, ok := strings.Cut(rest, "/")
+	if !ok || purlType == "" || p == "" {
+		return nil, invalid("missing type or name")
+	}
+	pat.purlType = strings.ToLower(purlType)
+
+	if i := strings.LastIndexByte(p, '/'); i >= 0 {
+		pat.namespace, p = p[:i], p[i+1:]
+	}
+	var err error
+	if pat.namespace, err = url.PathUnescape(pat.namespace); err != nil {
+		return nil, invalid("namespace: %v", err)
+	}
+	if pat.name, err = url.PathUnescape(p); err != nil {
+		return nil, invalid("name: %v", err)
+	}
+	pat.namespace, pat.name, _ = normalizeComponents(pat.purlType, pat.namespace, pat.name, "", "")
+
+	for _, glob := range []string{pat.purlType, pat.namespace, pat.name, pat.version} {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, invalid("%v", err)
+		}
+	}
+
+	return pat, nil
+}
+
+// MustCompilePattern is like CompilePattern but panics if the pattern is invalid.
+func MustCompilePattern(s string) *Pattern {
+	p, err := CompilePattern(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the pattern source.
+func (pat *Pattern) String() string {
+	return pat.raw
+}
+
+// Match reports whether p matches the pattern.
+func (pat *Pattern) Match(p *PURL) bool {
+	if p == nil {
+		return false
+	}
+	c := p.canonical(equalOptions{})
+
+	if !globMatch(pat.purlType, c.Type) ||
+		!globMatch(pat.namespace, c.Namespace) ||
+		!globMatch(pat.name, c.Name) {
+		return false
+	}
+
+	switch {
+	case pat.versions != nil:
+		if c.Version == "" || !pat.versions.Contains(c.Version) {
+			return false
+		}
+	case pat.version != "":
+		if !globMatch(pat.version, c.Version) {
+			return false
+		}
+	}
+
+	if len(pat.qualifiers) > 0 {
+		quals := c.Qualifiers.Map()
+		for k, v := range pat.qualifiers {
+			if quals[k] != v {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// MatchString parses s and reports whether it matches the pattern.
+// Strings that fail to parse never match.
+func (pat *Pattern) MatchString(s string) bool {
+	p, err := Parse(s)
+	if err != nil {
+		return false
+	}
+	return pat.Match(p)
+}
+
+// globMatch matches name against a path.Match pattern. A namespace glob
+// therefore matches one namespace per "/"-separated segment.
+func globMatch(pattern, name string) bool {
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
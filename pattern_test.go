@@ -0,0 +1,91 @@
+package purl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		purl    string
+		want    bool
+	}{
+		// Exact
+		{"pkg:npm/lodash", "pkg:npm/lodash@4.17.21", true},
+		{"pkg:npm/lodash", "pkg:npm/underscore@1.0.0", false},
+		{"pkg:npm/lodash", "pkg:cargo/lodash", false},
+
+		// Wildcards
+		{"pkg:npm/@acme/*", "pkg:npm/%40acme/widgets@1.0.0", true},
+		{"pkg:npm/@acme/*", "pkg:npm/%40other/widgets@1.0.0", false},
+		{"pkg:npm/@acme/*", "pkg:npm/widgets@1.0.0", false},
+		{"pkg:npm/*", "pkg:npm/lodash", true},
+		{"pkg:npm/*", "pkg:npm/%40acme/widgets", false},
+		{"pkg:*/serde", "pkg:cargo/serde", true},
+		{"pkg:golang/github.com/acme/*", "pkg:golang/github.com/acme/tool@v1.2.0", true},
+		{"pkg:golang/github.com/*/tool", "pkg:golang/github.com/acme/tool", true},
+
+		// Version globs
+		{"pkg:pypi/django@4.*", "pkg:pypi/django@4.2.1", true},
+		{"pkg:pypi/django@4.*", "pkg:pypi/django@5.0.0", false},
+		{"pkg:pypi/django@4.2.1", "pkg:pypi/django", false},
+
+		// Version ranges
+		{"pkg:npm/@acme/*@vers:npm/>=1.0.0", "pkg:npm/%40acme/widgets@1.2.0", true},
+		{"pkg:npm/@acme/*@vers:npm/>=1.0.0", "pkg:npm/%40acme/widgets@0.9.0", false},
+		{"pkg:npm/@acme/*@vers:npm/>=1.0.0", "pkg:npm/%40acme/widgets", false},
+		{"pkg:maven/org.apache.logging.log4j/log4j-core@vers:maven/>=2.0.0|<2.17.1", "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", true},
+		{"pkg:maven/org.apache.logging.log4j/log4j-core@vers:maven/>=2.0.0|<2.17.1", "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1", false},
+
+		// Per-type name normalization
+		{"pkg:pypi/Django_REST", "pkg:pypi/django-rest@3.0", true},
+		{"pkg:npm/lod[a-z]sh", "pkg:npm/lodash@4.17.21", true},
+		{"pkg:golang/GitHub.com/Acme/*", "pkg:golang/github.com/acme/tool", true},
+
+		// Qualifiers
+		{"pkg:deb/debian/*?arch=amd64", "pkg:deb/debian/curl@7.50.3?arch=amd64&distro=jessie", true},
+		{"pkg:deb/debian/*?arch=amd64", "pkg:deb/debian/curl@7.50.3?arch=i386", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"~"+tt.purl, func(t *testing.T) {
+			pat, err := CompilePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("CompilePattern(%q) error: %v", tt.pattern, err)
+			}
+			if got := pat.MatchString(tt.purl); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.purl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePatternErrors(t *testing.T) {
+	tests := []string{
+		"npm/lodash",
+		"pkg:npm",
+		"pkg:npm/",
+		"pkg:npm/[abc",
+		"pkg:npm/lodash@vers:npm",
+		"pkg:npm/lod?sh",
+	}
+
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			if _, err := CompilePattern(s); !errors.Is(err, ErrInvalidPattern) {
+				t.Errorf("CompilePattern(%q) error = %v, want ErrInvalidPattern", s, err)
+			}
+		})
+	}
+}
+
+func TestPatternMatchNil(t *testing.T) {
+	pat := MustCompilePattern("pkg:npm/*")
+	if pat.Match(nil) {
+		t.Error("Match(nil) = true, want false")
+	}
+	if got := pat.String(); got != "pkg:npm/*" {
+		t.Errorf("String() = %q, want %q", got, "pkg:npm/*")
+	}
+}
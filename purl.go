@@ -17,10 +17,31 @@ type PURL struct {
 }
 
 // Parse parses a Package URL string into a PURL.
+// On failure it returns a *ParseError identifying the rejected component.
 func Parse(s string) (*PURL, error) {
+	return defaultRegistry().Parse(s)
+}
+
+// ParseWithOptions is Parse with options such as CanonicalTypes, which are
+// resolved against the default registry.
+func ParseWithOptions(s string, opts ...ParseOption) (*PURL, error) {
+	return defaultRegistry().Parse(s, opts...)
+}
+
+// Parse parses a Package URL string into a PURL, resolving options such as
+// CanonicalTypes against this registry's type catalog.
+func (r *Registry) Parse(s string, opts ...ParseOption) (*PURL, error) {
 	p, err := packageurl.FromString(s)
 	if err != nil {
-		return nil, err
+		return nil, r.newParseError(s, err)
+	}
+
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.canonicalTypes {
+		return r.canonicalize(p, o.warn)
 	}
 	return &PURL{p}, nil
 }
@@ -56,7 +77,7 @@ func (p *PURL) String() string {
 
 // RepositoryURL returns the repository_url qualifier value, if present.
 func (p *PURL) RepositoryURL() string {
-	return p.Qualifiers.Map()["repository_url"]
+	return p.Qualifier(QualifierRepositoryURL)
 }
 
 // IsPrivateRegistry returns true if the PURL has a non-default repository_url.
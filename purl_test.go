@@ -4,6 +4,9 @@ import (
 	"testing"
 )
 
+// Parse keeps its plain signature so it can be passed as a function value.
+var _ func(string) (*PURL, error) = Parse
+
 func TestParse(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,308 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// Standard qualifier keys defined by the PURL specification.
+const (
+	QualifierRepositoryURL = "repository_url"
+	QualifierDownloadURL   = "download_url"
+	QualifierVCSURL        = "vcs_url"
+	QualifierFileName      = "file_name"
+	QualifierChecksum      = "checksum"
+	QualifierArch          = "arch"
+	QualifierDistro        = "distro"
+)
+
+// standardQualifiers are the qualifiers the PURL spec allows on every type.
+var standardQualifiers = []QualifierDefinition{
+	{Key: QualifierRepositoryURL, Description: "Repository or registry URL to use instead of the default."},
+	{Key: QualifierDownloadURL, Description: "URL of the package archive."},
+	{Key: QualifierVCSURL, Description: "Version control URL, in SPDX VCS form."},
+	{Key: QualifierFileName, Description: "File name of the package archive."},
+	{
+		Key:         QualifierChecksum,
+		Description: "Comma-separated algorithm:value checksums of the package archive.",
+		Pattern:     `^[A-Za-z0-9-]+:[0-9A-Fa-f]+(,[A-Za-z0-9-]+:[0-9A-Fa-f]+)*$`,
+	},
+}
+
+// ErrUnknownQualifier is returned when a qualifier is neither standard nor
+// defined for the PURL's type.
+var ErrUnknownQualifier = errors.New("unknown qualifier")
+
+// ErrInvalidQualifierValue is returned when a qualifier value does not match
+// the pattern defined for it.
+var ErrInvalidQualifierValue = errors.New("invalid qualifier value")
+
+// ErrMissingQualifier is returned when a qualifier the type requires is absent.
+var ErrMissingQualifier = errors.New("required qualifier is missing")
+
+// QualifierError describes a problem with one qualifier.
+// It unwraps to one of the Err* sentinel errors so callers can use errors.Is.
+type QualifierError struct {
+	Type       string // PURL type being validated
+	Key        string // qualifier key as written in the PURL
+	Suggestion string // closest defined key for an unknown qualifier, if any
+	Err        error
+}
+
+func (e *QualifierError) Error() string {
+	msg := fmt.Sprintf("invalid %s purl: qualifier %q: %v", e.Type, e.Key, e.Err)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *QualifierError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateQualifiers checks the PURL's qualifiers against the definitions for
+// its type. See Registry.ValidateQualifiers.
+func ValidateQualifiers(p *PURL) error {
+	return defaultRegistry().ValidateQualifiers(p)
+}
+
+// ValidateQualifiers checks the PURL's qualifiers against the standard
+// qualifiers and the definitions for its type in this registry. It reports
+// malformed keys, keys the type does not define, values that do not match
+// their pattern, and missing required qualifiers. For types the registry
+// does not know, only keys and standard qualifier values are checked.
+//
+// All problems are returned together, joined with errors.Join, each as a
+// *QualifierError.
+func (r *Registry) ValidateQualifiers(p *PURL) error {
+	cfg, known := r.lookupType(p.Type)
+	defs := make(map[string]QualifierDefinition, len(standardQualifiers)+len(cfg.Qualifiers))
+	for _, d := range standardQualifiers {
+		defs[d.Key] = d
+	}
+	for _, d := range cfg.Qualifiers {
+		defs[d.Key] = d
+	}
+
+	var errs []error
+	report := func(key string, err error) {
+		errs = append(errs, &QualifierError{Type: p.Type, Key: key, Err: err})
+	}
+
+	for _, q := range p.Qualifiers {
+		if err := ValidateQualifierKey(q.Key); err != nil {
+			report(q.Key, err)
+			continue
+		}
+		d, ok := defs[strings.ToLower(q.Key)]
+		if !ok {
+			if known {
+				errs = append(errs, &QualifierError{
+					Type:       p.Type,
+					Key:        q.Key,
+					Suggestion: closestKey(strings.ToLower(q.Key), defs),
+					Err:        ErrUnknownQualifier,
+				})
+			}
+			continue
+		}
+		if d.Pattern == "" {
+			continue
+		}
+		re, err := r.compileRegex(d.Pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(q.Value) {
+			report(q.Key, fmt.Errorf("%w %q", ErrInvalidQualifierValue, q.Value))
+		}
+	}
+
+	for _, d := range cfg.Qualifiers {
+		if d.Required && p.Qualifier(d.Key) == "" {
+			report(d.Key, ErrMissingQualifier)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// closestKey returns the defined key nearest to key by edit distance, if it
+// is close enough to be a likely typo.
+func closestKey(key string, defs map[string]QualifierDefinition) string {
+	const maxDistance = 2
+	best, bestDist := "", maxDistance+1
+	for k := range defs {
+		if d := editDistance(key, k); d < bestDist || (d == bestDist && k < best) {
+			best, bestDist = k, d
+		}
+	}
+	if bestDist > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Checksum is one algorithm:value pair from the checksum qualifier.
+type Checksum struct {
+	Algorithm string // lowercase algorithm name, e.g. "sha256"
+	Value     string // hex digest as written in the qualifier
+}
+
+// String returns the checksum in qualifier form, "algorithm:value".
+func (c Checksum) String() string {
+	return c.Algorithm + ":" + c.Value
+}
+
+// ValidateQualifierKey checks a qualifier key against the PURL spec: ASCII
+// letters, digits, '.', '-' and '_', not starting with a digit. Uppercase
+// letters are accepted because keys are lowercased when normalized.
+func ValidateQualifierKey(key string) error {
+	if !packageurl.QualifierKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: key %q", ErrInvalidQualifier, key)
+	}
+	return nil
+}
+
+// WithQualifiers returns a copy of the PURL with each qualifier in the map
+// set, replacing existing values. Keys are validated and lowercased; new keys
+// are appended in sorted order.
+func (p *PURL) WithQualifiers(qualifiers map[string]string) (*PURL, error) {
+	keys := make([]string, 0, len(qualifiers))
+	for k := range qualifiers {
+		if err := ValidateQualifierKey(k); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	q := make(packageurl.Qualifiers, len(p.Qualifiers), len(p.Qualifiers)+len(keys))
+	copy(q, p.Qualifiers)
+	for _, k := range keys {
+		key := strings.ToLower(k)
+		replaced := false
+		for i := range q {
+			if q[i].Key == key {
+				q[i].Value = qualifiers[k]
+				replaced = true
+			}
+		}
+		if !replaced {
+			q = append(q, packageurl.Qualifier{Key: key, Value: qualifiers[k]})
+		}
+	}
+	return p.withQualifiers(q), nil
+}
+
+// WithoutQualifier returns a copy of the PURL with the qualifier removed.
+// The key is validated and lowercased as in WithQualifiers.
+func (p *PURL) WithoutQualifier(key string) (*PURL, error) {
+	if err := ValidateQualifierKey(key); err != nil {
+		return nil, err
+	}
+	key = strings.ToLower(key)
+
+	q := make(packageurl.Qualifiers, 0, len(p.Qualifiers))
+	for _, qual := range p.Qualifiers {
+		if strings.ToLower(qual.Key) != key {
+			q = append(q, qual)
+		}
+	}
+	return p.withQualifiers(q), nil
+}
+
+// WithoutQualifiers returns a copy of the PURL with all qualifiers removed.
+func (p *PURL) WithoutQualifiers() *PURL {
+	return p.withQualifiers(nil)
+}
+
+// WithSubpath returns a copy of the PURL with a different subpath.
+// Leading and trailing slashes are trimmed.
+func (p *PURL) WithSubpath(subpath string) *PURL {
+	cp := p.withQualifiers(p.Qualifiers)
+	cp.Subpath = strings.Trim(subpath, "/")
+	return cp
+}
+
+// Arch returns the arch qualifier value, if present.
+func (p *PURL) Arch() string {
+	return p.Qualifier(QualifierArch)
+}
+
+// Distro returns the distro qualifier value, if present.
+func (p *PURL) Distro() string {
+	return p.Qualifier(QualifierDistro)
+}
+
+// DownloadURLQualifier returns the download_url qualifier value, if present.
+// DownloadURL returns it too, or else derives the artifact location.
+func (p *PURL) DownloadURLQualifier() string {
+	return p.Qualifier(QualifierDownloadURL)
+}
+
+// VCSURL returns the vcs_url qualifier value, if present.
+func (p *PURL) VCSURL() string {
+	return p.Qualifier(QualifierVCSURL)
+}
+
+// FileName returns the file_name qualifier value, if present.
+func (p *PURL) FileName() string {
+	return p.Qualifier(QualifierFileName)
+}
+
+// Checksums parses the checksum qualifier, a comma-separated list of
+// algorithm:value pairs such as "sha256:ab12...,sha1:cd34...". Entries
+// without an algorithm or value are skipped here; VerifyChecksum reports
+// them.
+func (p *PURL) Checksums() []Checksum {
+	raw := p.Qualifier(QualifierChecksum)
+	if raw == "" {
+		return nil
+	}
+	checksums, _ := parseChecksums(raw)
+	return checksums
+}
+
+// withQualifiers returns a copy of the PURL using q as its qualifiers.
+func (p *PURL) withQualifiers(q packageurl.Qualifiers) *PURL {
+	if len(q) == 0 {
+		q = nil
+	}
+	return &PURL{
+		PackageURL: packageurl.PackageURL{
+			Type:       p.Type,
+			Namespace:  p.Namespace,
+			Name:       p.Name,
+			Version:    p.Version,
+			Qualifiers: q,
+			Subpath:    p.Subpath,
+		},
+	}
+}
@@ -0,0 +1,184 @@
+package purl
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWithQualifiers(t *testing.T) {
+	p, _ := Parse("pkg:deb/debian/curl@7.50.3-1?arch=i386")
+
+	p2, err := p.WithQualifiers(map[string]string{"distro": "jessie", "Arch": "amd64"})
+	if err != nil {
+		t.Fatalf("WithQualifiers() error: %v", err)
+	}
+	if got, want := p2.String(), "pkg:deb/debian/curl@7.50.3-1?arch=amd64&distro=jessie"; got != want {
+		t.Errorf("WithQualifiers() = %q, want %q", got, want)
+	}
+	if got := p.Arch(); got != "i386" {
+		t.Errorf("original Arch() = %q, want %q", got, "i386")
+	}
+
+	if _, err := p.WithQualifiers(map[string]string{"1bad": "x"}); !errors.Is(err, ErrInvalidQualifier) {
+		t.Errorf("WithQualifiers(invalid key) error = %v, want ErrInvalidQualifier", err)
+	}
+}
+
+func TestWithoutQualifier(t *testing.T) {
+	p, _ := Parse("pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie")
+
+	p2, err := p.WithoutQualifier("arch")
+	if err != nil {
+		t.Fatalf("WithoutQualifier() error: %v", err)
+	}
+	if got, want := p2.String(), "pkg:deb/debian/curl@7.50.3-1?distro=jessie"; got != want {
+		t.Errorf("WithoutQualifier() = %q, want %q", got, want)
+	}
+
+	p2, err = p.WithoutQualifier("DISTRO")
+	if err != nil {
+		t.Fatalf("WithoutQualifier(DISTRO) error: %v", err)
+	}
+	if got, want := p2.String(), "pkg:deb/debian/curl@7.50.3-1?arch=i386"; got != want {
+		t.Errorf("WithoutQualifier(DISTRO) = %q, want %q", got, want)
+	}
+
+	if _, err := p.WithoutQualifier("bad key"); !errors.Is(err, ErrInvalidQualifier) {
+		t.Errorf("WithoutQualifier(bad key) error = %v, want ErrInvalidQualifier", err)
+	}
+	if got := p.Arch(); got != "i386" {
+		t.Errorf("original Arch() = %q, want %q", got, "i386")
+	}
+
+	p3 := p.WithoutQualifiers()
+	if got, want := p3.String(), "pkg:deb/debian/curl@7.50.3-1"; got != want {
+		t.Errorf("WithoutQualifiers() = %q, want %q", got, want)
+	}
+}
+
+func TestWithSubpath(t *testing.T) {
+	p, _ := Parse("pkg:golang/google.golang.org/genproto@v1.0.0")
+	p2 := p.WithSubpath("/googleapis/api/annotations/")
+	if got, want := p2.String(), "pkg:golang/google.golang.org/genproto@v1.0.0#googleapis/api/annotations"; got != want {
+		t.Errorf("WithSubpath() = %q, want %q", got, want)
+	}
+	if p.Subpath != "" {
+		t.Errorf("original Subpath = %q, want empty", p.Subpath)
+	}
+}
+
+func TestStandardQualifierAccessors(t *testing.T) {
+	p, _ := Parse("pkg:generic/openssl@1.1.10g?arch=x86_64&distro=fedora-25" +
+		"&download_url=https://openssl.org/source/openssl-1.1.0g.tar.gz" +
+		"&vcs_url=git%2Bhttps://github.com/openssl/openssl" +
+		"&file_name=openssl-1.1.0g.tar.gz")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Arch", p.Arch(), "x86_64"},
+		{"Distro", p.Distro(), "fedora-25"},
+		{"DownloadURLQualifier", p.DownloadURLQualifier(), "https://openssl.org/source/openssl-1.1.0g.tar.gz"},
+		{"VCSURL", p.VCSURL(), "git+https://github.com/openssl/openssl"},
+		{"FileName", p.FileName(), "openssl-1.1.0g.tar.gz"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestChecksums(t *testing.T) {
+	p, _ := Parse("pkg:generic/openssl@1.1.10g?checksum=SHA256:de4d501267da,sha1:ad9503c3e994,bogus")
+	want := []Checksum{
+		{Algorithm: "sha256", Value: "de4d501267da"},
+		{Algorithm: "sha1", Value: "ad9503c3e994"},
+	}
+	if got := p.Checksums(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Checksums() = %v, want %v", got, want)
+	}
+	if got := want[0].String(); got != "sha256:de4d501267da" {
+		t.Errorf("Checksum.String() = %q, want %q", got, "sha256:de4d501267da")
+	}
+
+	p2, _ := Parse("pkg:generic/openssl@1.1.10g")
+	if got := p2.Checksums(); got != nil {
+		t.Errorf("Checksums() = %v, want nil", got)
+	}
+}
+
+func TestValidateQualifiers(t *testing.T) {
+	tests := []struct {
+		purl    string
+		wantErr error
+		wantKey string
+	}{
+		{"pkg:maven/org.apache.commons/commons-lang3@3.12.0?classifier=sources&type=jar", nil, ""},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.12.0?repository_url=https://repo.example.com", nil, ""},
+		{"pkg:npm/lodash@4.17.21?checksum=sha256:de4d501267da", nil, ""},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.12.0?clasifier=sources", ErrUnknownQualifier, "clasifier"},
+		{"pkg:npm/lodash@4.17.21?arch=x86_64", ErrUnknownQualifier, "arch"},
+		{"pkg:rpm/fedora/curl@7.50.3-1.fc25?epoch=one", ErrInvalidQualifierValue, "epoch"},
+		{"pkg:npm/lodash@4.17.21?checksum=sha256", ErrInvalidQualifierValue, "checksum"},
+		{"pkg:swid/Acme/example.com/Enterprise+Server@1.0.0", ErrMissingQualifier, "tag_id"},
+		{"pkg:unknowntype/foo@1.0?anything=goes", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			err = ValidateQualifiers(p)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("ValidateQualifiers() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateQualifiers() error = %v, want %v", err, tt.wantErr)
+			}
+			var qe *QualifierError
+			if !errors.As(err, &qe) || qe.Key != tt.wantKey {
+				t.Errorf("QualifierError = %+v, want key %q", qe, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestValidateQualifiersSuggestion(t *testing.T) {
+	p, _ := Parse("pkg:maven/org.apache.commons/commons-lang3@3.12.0?clasifier=sources&tpye=jar")
+	err := ValidateQualifiers(p)
+
+	want := map[string]string{"clasifier": "classifier", "tpye": "type"}
+	got := map[string]string{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var qe *QualifierError
+		if errors.As(e, &qe) {
+			got[qe.Key] = qe.Suggestion
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("suggestions = %v, want %v", got, want)
+	}
+}
+
+func TestValidateQualifiersExamples(t *testing.T) {
+	for _, typ := range KnownTypes() {
+		for _, ex := range TypeInfo(typ).Examples {
+			p, err := Parse(ex)
+			if err != nil {
+				continue
+			}
+			if err := ValidateQualifiers(p); err != nil {
+				t.Errorf("ValidateQualifiers(%s) error: %v", ex, err)
+			}
+		}
+	}
+}
@@ -5,40 +5,71 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
-	"sync"
 )
 
 // ErrNoRegistryConfig is returned when a PURL type has no registry configuration.
 var ErrNoRegistryConfig = errors.New("no registry configuration for this type")
 
+// ErrNoRegistryAPI is returned when a PURL type has no registry API template.
+var ErrNoRegistryAPI = errors.New("no registry API for this type")
+
 // ErrNoMatch is returned when a URL doesn't match the reverse regex.
 var ErrNoMatch = errors.New("URL does not match any known registry pattern")
 
-// regexCache caches compiled regular expressions by pattern.
-var regexCache sync.Map
-
 // RegistryURL returns the human-readable registry URL for the package.
 // For example, pkg:npm/lodash returns "https://www.npmjs.com/package/lodash".
+//
+// When the PURL has a repository_url other than the type's default
+// registry, the URL is built against it using the layout of the registry
+// product it points at, such as Artifactory, Nexus or Verdaccio. If the
+// product is not recognized, or has no such URL for the type, a
+// *RegistryLayoutError is returned. The same applies to RegistryAPIURL and
+// DownloadURL.
 func (p *PURL) RegistryURL() (string, error) {
-	cfg := TypeInfo(p.Type)
+	return defaultRegistry().RegistryURL(p)
+}
+
+// RegistryURLWithVersion returns the registry URL including version.
+// Falls back to RegistryURL if version URLs aren't supported.
+func (p *PURL) RegistryURLWithVersion() (string, error) {
+	return defaultRegistry().RegistryURLWithVersion(p)
+}
+
+// RegistryURL returns the human-readable registry URL for the package using
+// this registry's type configuration.
+func (r *Registry) RegistryURL(p *PURL) (string, error) {
+	cfg := r.TypeInfo(p.Type)
 	if cfg == nil || cfg.RegistryConfig == nil {
 		return "", ErrNoRegistryConfig
 	}
+	if repoURL := r.privateRegistry(p); repoURL != "" {
+		return layoutURL(p, cfg.RegistryConfig, repoURL, "registry", func(t layoutTemplates) string {
+			return t.registry
+		})
+	}
 
 	return expandTemplate(cfg.RegistryConfig, p.Namespace, p.Name, "")
 }
 
-// RegistryURLWithVersion returns the registry URL including version.
-// Falls back to RegistryURL if version URLs aren't supported.
-func (p *PURL) RegistryURLWithVersion() (string, error) {
+// RegistryURLWithVersion returns the registry URL including version using
+// this registry's type configuration.
+func (r *Registry) RegistryURLWithVersion(p *PURL) (string, error) {
 	if p.Version == "" {
-		return p.RegistryURL()
+		return r.RegistryURL(p)
 	}
 
-	cfg := TypeInfo(p.Type)
+	cfg := r.TypeInfo(p.Type)
 	if cfg == nil || cfg.RegistryConfig == nil {
 		return "", ErrNoRegistryConfig
 	}
+	if repoURL := r.privateRegistry(p); repoURL != "" {
+		return layoutURL(p, cfg.RegistryConfig, repoURL, "registry", func(t layoutTemplates) string {
+			if t.registryWithVersion != "" {
+				return t.registryWithVersion
+			}
+			return t.registry
+		})
+	}
 
 	return expandTemplate(cfg.RegistryConfig, p.Namespace, p.Name, p.Version)
 }
@@ -76,30 +107,92 @@ func expandTemplate(rc *RegistryConfig, namespace, name, version string) (string
 		return "", ErrNoRegistryConfig
 	}
 
+	return fillTemplate(template, &rc.Components, namespace, name, version), nil
+}
+
+// fillTemplate substitutes PURL components into a URL template. Each
+// namespace segment is escaped separately so multi-segment namespaces such
+// as Go module paths keep their slashes.
+func fillTemplate(template string, comp *RegistryComponents, namespace, name, version string) string {
 	// Handle namespace prefix (e.g., @ for npm)
 	displayNamespace := namespace
-	if rc.Components.NamespacePrefix != "" && namespace != "" {
+	if comp.NamespacePrefix != "" && namespace != "" {
 		// Add prefix if not already present
-		if !strings.HasPrefix(namespace, rc.Components.NamespacePrefix) {
-			displayNamespace = rc.Components.NamespacePrefix + namespace
+		if !strings.HasPrefix(namespace, comp.NamespacePrefix) {
+			displayNamespace = comp.NamespacePrefix + namespace
 		}
 	}
 
+	segments := strings.Split(displayNamespace, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+
 	// Expand template variables
 	result := template
-	result = strings.ReplaceAll(result, "{namespace}", url.PathEscape(displayNamespace))
+	result = strings.ReplaceAll(result, "{namespace}", strings.Join(segments, "/"))
+	result = strings.ReplaceAll(result, "{namespace_path}", strings.ReplaceAll(strings.Join(segments, "/"), ".", "/"))
 	result = strings.ReplaceAll(result, "{name}", url.PathEscape(name))
+	result = strings.ReplaceAll(result, "{name_lower}", url.PathEscape(strings.ToLower(name)))
 	result = strings.ReplaceAll(result, "{version}", url.PathEscape(version))
+	result = strings.ReplaceAll(result, "{version_lower}", url.PathEscape(strings.ToLower(version)))
+
+	return result
+}
 
-	return result, nil
+// RegistryAPIURL returns the machine-readable metadata endpoint for the
+// package, such as "https://registry.npmjs.org/lodash" for pkg:npm/lodash.
+// It returns ErrNoRegistryAPI if the type declares no API template.
+func (p *PURL) RegistryAPIURL() (string, error) {
+	return defaultRegistry().RegistryAPIURL(p)
+}
+
+// RegistryAPIURL returns the package's metadata endpoint using this
+// registry's api_template for the type.
+func (r *Registry) RegistryAPIURL(p *PURL) (string, error) {
+	cfg := r.TypeInfo(p.Type)
+	if cfg == nil || cfg.RegistryConfig == nil {
+		return "", ErrNoRegistryConfig
+	}
+	rc := cfg.RegistryConfig
+	if repoURL := r.privateRegistry(p); repoURL != "" {
+		return layoutURL(p, rc, repoURL, "API", func(t layoutTemplates) string {
+			return t.api
+		})
+	}
+
+	template := rc.APITemplate
+	if p.Namespace == "" && rc.APITemplateNoNamespace != "" {
+		template = rc.APITemplateNoNamespace
+	}
+	if template == "" {
+		return "", ErrNoRegistryAPI
+	}
+
+	return fillTemplate(template, &rc.Components, p.Namespace, p.Name, p.Version), nil
 }
 
 // ParseRegistryURL attempts to parse a registry URL into a PURL.
-// It tries all known types to find a match.
+// It tries the known types whose registry is on the URL's host.
 func ParseRegistryURL(url string) (*PURL, error) {
-	for _, t := range KnownTypes() {
-		p, err := ParseRegistryURLWithType(url, t)
-		if err == nil {
+	return defaultRegistry().ParseRegistryURL(url)
+}
+
+// ParseRegistryURLWithType parses a registry URL using a specific PURL type.
+func ParseRegistryURLWithType(url, purlType string) (*PURL, error) {
+	return defaultRegistry().ParseRegistryURLWithType(url, purlType)
+}
+
+// ParseRegistryURL attempts to parse a registry URL into a PURL.
+// It tries the types whose reverse_regex can match the URL's host, in type
+// name order, and returns the first match.
+func (r *Registry) ParseRegistryURL(url string) (*PURL, error) {
+	r.mu.RLock()
+	candidates := r.hosts.candidates(url)
+	r.mu.RUnlock()
+
+	for _, c := range candidates {
+		if p := registryURLToPURL(c.re, &c.comp, c.purlType, url); p != nil {
 			return p, nil
 		}
 	}
@@ -107,27 +200,37 @@ func ParseRegistryURL(url string) (*PURL, error) {
 }
 
 // ParseRegistryURLWithType parses a registry URL using a specific PURL type.
-func ParseRegistryURLWithType(url, purlType string) (*PURL, error) {
-	cfg := TypeInfo(purlType)
+func (r *Registry) ParseRegistryURLWithType(url, purlType string) (*PURL, error) {
+	cfg := r.TypeInfo(purlType)
 	if cfg == nil || cfg.RegistryConfig == nil || cfg.RegistryConfig.ReverseRegex == "" {
 		return nil, ErrNoRegistryConfig
 	}
 
-	re, err := getOrCompileRegex(cfg.RegistryConfig.ReverseRegex)
+	re, err := r.compileRegex(cfg.RegistryConfig.ReverseRegex)
 	if err != nil {
 		return nil, err
 	}
 
+	p := registryURLToPURL(re, &cfg.RegistryConfig.Components, purlType, url)
+	if p == nil {
+		return nil, ErrNoMatch
+	}
+	return p, nil
+}
+
+// registryURLToPURL matches url against a type's reverse regex and builds
+// the PURL from the captured components, or returns nil if it doesn't match.
+func registryURLToPURL(re *regexp.Regexp, comp *RegistryComponents, purlType, url string) *PURL {
 	matches := re.FindStringSubmatch(url)
 	if matches == nil {
-		return nil, ErrNoMatch
+		return nil
 	}
 
 	var namespace, name, version string
 
 	// Parse matches based on component configuration
-	if cfg.RegistryConfig.Components.Namespace {
-		if cfg.RegistryConfig.Components.NamespaceRequired {
+	if comp.Namespace {
+		if comp.NamespaceRequired {
 			// Namespace is required: matches[1]=namespace, matches[2]=name, matches[3]=version (if present)
 			if len(matches) > 1 {
 				namespace = matches[1]
@@ -159,21 +262,21 @@ func ParseRegistryURLWithType(url, purlType string) (*PURL, error) {
 	}
 
 	if name == "" {
-		return nil, ErrNoMatch
+		return nil
 	}
 
-	return New(purlType, namespace, name, version, nil), nil
+	return New(purlType, namespace, name, version, nil)
 }
 
-// getOrCompileRegex returns a cached compiled regex or compiles and caches it.
-func getOrCompileRegex(pattern string) (*regexp.Regexp, error) {
-	if cached, ok := regexCache.Load(pattern); ok {
+// compileRegex returns a cached compiled regex or compiles and caches it.
+func (r *Registry) compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := r.regexCache.Load(pattern); ok {
 		return cached.(*regexp.Regexp), nil
 	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, err
 	}
-	regexCache.Store(pattern, re)
+	r.regexCache.Store(pattern, re)
 	return re, nil
 }
@@ -1,6 +1,7 @@
 package purl
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -161,6 +162,10 @@ func TestParseRegistryURLWithType(t *testing.T) {
 		{"https://mvnrepository.com/artifact/org.apache.commons/commons-lang3", "maven", "pkg:maven/org.apache.commons/commons-lang3", false},
 		{"https://mvnrepository.com/artifact/org.apache.commons/commons-lang3/3.12.0", "maven", "pkg:maven/org.apache.commons/commons-lang3@3.12.0", false},
 
+		// golang
+		{"https://pkg.go.dev/github.com/gorilla/mux", "golang", "pkg:golang/github.com/gorilla/mux", false},
+		{"https://pkg.go.dev/github.com/gorilla/mux@v1.8.0", "golang", "pkg:golang/github.com/gorilla/mux@v1.8.0", false},
+
 		// nuget
 		{"https://www.nuget.org/packages/Newtonsoft.Json", "nuget", "pkg:nuget/Newtonsoft.Json", false},
 		{"https://nuget.org/packages/Newtonsoft.Json/13.0.1", "nuget", "pkg:nuget/Newtonsoft.Json@13.0.1", false},
@@ -233,3 +238,100 @@ func TestParseRegistryURL(t *testing.T) {
 		})
 	}
 }
+
+// The golang reverse_regex once captured the whole path in one group, so
+// ParseRegistryURL returned ErrNoMatch for every pkg.go.dev URL.
+func TestParseRegistryURLGoModule(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://pkg.go.dev/github.com/gorilla/mux", "pkg:golang/github.com/gorilla/mux"},
+		{"https://pkg.go.dev/github.com/gorilla/mux@v1.8.0", "pkg:golang/github.com/gorilla/mux@v1.8.0"},
+		{"https://pkg.go.dev/github.com/gorilla/mux@v1.8.0?tab=versions", "pkg:golang/github.com/gorilla/mux@v1.8.0"},
+		{"https://pkg.go.dev/golang.org/x/text/v2", "pkg:golang/golang.org/x/text/v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			p, err := ParseRegistryURL(tt.url)
+			if err != nil {
+				t.Fatalf("ParseRegistryURL() error: %v", err)
+			}
+			if got := p.String(); got != tt.want {
+				t.Errorf("ParseRegistryURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryAPIURL(t *testing.T) {
+	tests := []struct {
+		purl    string
+		want    string
+		wantErr error
+	}{
+		{"pkg:npm/lodash@4.17.21", "https://registry.npmjs.org/lodash", nil},
+		{"pkg:npm/%40babel/core", "https://registry.npmjs.org/@babel/core", nil},
+		{"pkg:pypi/requests", "https://pypi.org/pypi/requests/json", nil},
+		{"pkg:cargo/serde", "https://crates.io/api/v1/crates/serde", nil},
+		{"pkg:gem/rails", "https://rubygems.org/api/v1/gems/rails.json", nil},
+		{"pkg:composer/laravel/framework", "https://repo.packagist.org/p2/laravel/framework.json", nil},
+		{"pkg:nuget/Newtonsoft.Json", "https://api.nuget.org/v3-flatcontainer/newtonsoft.json/index.json", nil},
+		{"pkg:golang/github.com/gorilla/mux", "https://proxy.golang.org/github.com/gorilla/mux/@v/list", nil},
+		{"pkg:hex/phoenix", "https://hex.pm/api/packages/phoenix", nil},
+		{"pkg:pub/http", "https://pub.dev/api/packages/http", nil},
+		{"pkg:github/torvalds/linux", "", ErrNoRegistryConfig},
+		{"pkg:maven/junit/junit", "", ErrNoRegistryAPI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			got, err := p.RegistryAPIURL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RegistryAPIURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RegistryAPIURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryURLMultiSegmentNamespace(t *testing.T) {
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{"pkg:golang/github.com/gorilla/mux", "https://pkg.go.dev/github.com/gorilla/mux"},
+		{"pkg:golang/golang.org/x/text", "https://pkg.go.dev/golang.org/x/text"},
+		{"pkg:golang/github.com/aws/aws-sdk-go-v2/service/s3", "https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/s3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			got, err := p.RegistryURL()
+			if err != nil {
+				t.Fatalf("RegistryURL() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RegistryURL() = %q, want %q", got, tt.want)
+			}
+			back, err := ParseRegistryURL(got)
+			if err != nil {
+				t.Fatalf("ParseRegistryURL(%q) error: %v", got, err)
+			}
+			if back.String() != p.String() {
+				t.Errorf("ParseRegistryURL(%q) = %s, want %s", got, back, p)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package purl
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// Scan implements sql.Scanner. It accepts string and []byte column values
+// and parses them with the same rules as Parse. Use NullPURL for nullable
+// columns.
+func (p *PURL) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+		return errors.New("purl: cannot scan NULL into PURL, use NullPURL")
+	default:
+		return fmt.Errorf("purl: cannot scan %T into PURL", src)
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*p = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the PURL as its canonical string.
+// The zero PURL, which MarshalText encodes as an empty string, is stored as
+// NULL and reads back through NullPURL as the zero PURL.
+func (p PURL) Value() (driver.Value, error) {
+	if p.Type == "" && p.Name == "" {
+		return nil, nil
+	}
+	return p.PackageURL.String(), nil
+}
+
+// NullPURL is a PURL that may be NULL. It implements sql.Scanner and
+// driver.Valuer, mirroring sql.NullString.
+type NullPURL struct {
+	PURL  PURL
+	Valid bool // Valid is true if PURL is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullPURL) Scan(src any) error {
+	if src == nil {
+		n.PURL, n.Valid = PURL{}, false
+		return nil
+	}
+	if err := n.PURL.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullPURL) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.PURL.Value()
+}
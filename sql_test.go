@@ -0,0 +1,184 @@
+package purl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// stubDriver is an in-memory database/sql driver holding a single text
+// column. "INSERT" appends its argument and "SELECT" returns every row.
+type stubDriver struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+type stubConn struct{ d *stubDriver }
+
+type stubStmt struct {
+	c     *stubConn
+	query string
+}
+
+type stubRows struct {
+	values []driver.Value
+	pos    int
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error) { return &stubConn{d}, nil }
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return &stubStmt{c: c, query: query}, nil
+}
+func (c *stubConn) Close() error              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (s *stubStmt) Close() error { return nil }
+func (s *stubStmt) NumInput() int {
+	if s.query == "INSERT" {
+		return 1
+	}
+	return 0
+}
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.d.mu.Lock()
+	defer s.c.d.mu.Unlock()
+	s.c.d.rows = append(s.c.d.rows, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.c.d.mu.Lock()
+	defer s.c.d.mu.Unlock()
+	return &stubRows{values: append([]driver.Value(nil), s.c.d.rows...)}, nil
+}
+
+func (r *stubRows) Columns() []string { return []string{"purl"} }
+func (r *stubRows) Close() error      { return nil }
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	dest[0] = r.values[r.pos]
+	r.pos++
+	return nil
+}
+
+func openStubDB(t *testing.T) *sql.DB {
+	t.Helper()
+	name := "purlstub-" + t.Name()
+	sql.Register(name, &stubDriver{})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("sql.Open error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestPURLSQLRoundTrip(t *testing.T) {
+	db := openStubDB(t)
+
+	p, _ := Parse("pkg:npm/%40babel/core@7.24.0")
+	if _, err := db.Exec("INSERT", p); err != nil {
+		t.Fatalf("Exec(PURL) error: %v", err)
+	}
+	if _, err := db.Exec("INSERT", NullPURL{}); err != nil {
+		t.Fatalf("Exec(NullPURL) error: %v", err)
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Query error: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var got []NullPURL
+	for rows.Next() {
+		var n NullPURL
+		if err := rows.Scan(&n); err != nil {
+			t.Fatalf("Scan error: %v", err)
+		}
+		got = append(got, n)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d rows, want 2", len(got))
+	}
+	if !got[0].Valid || got[0].PURL.String() != p.String() {
+		t.Errorf("row 0 = %+v, want %q", got[0], p.String())
+	}
+	if got[1].Valid {
+		t.Errorf("row 1 Valid = true, want false")
+	}
+}
+
+func TestZeroPURLSQLRoundTrip(t *testing.T) {
+	v, err := PURL{}.Value()
+	if err != nil || v != nil {
+		t.Fatalf("zero PURL Value() = %v, %v; want nil", v, err)
+	}
+
+	db := openStubDB(t)
+	if _, err := db.Exec("INSERT", PURL{}); err != nil {
+		t.Fatalf("Exec(PURL{}) error: %v", err)
+	}
+	var n NullPURL
+	if err := db.QueryRow("SELECT").Scan(&n); err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+	if n.Valid || n.PURL.Type != "" || n.PURL.Name != "" {
+		t.Errorf("round trip = %+v, want invalid zero PURL", n)
+	}
+}
+
+func TestPURLScan(t *testing.T) {
+	tests := []struct {
+		src     any
+		want    string
+		wantErr bool
+	}{
+		{"pkg:cargo/serde@1.0.0", "pkg:cargo/serde@1.0.0", false},
+		{[]byte("pkg:gem/rails"), "pkg:gem/rails", false},
+		{"not a purl", "", true},
+		{nil, "", true},
+		{42, "", true},
+	}
+
+	for _, tt := range tests {
+		var p PURL
+		err := p.Scan(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Scan(%v) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && p.String() != tt.want {
+			t.Errorf("Scan(%v) = %q, want %q", tt.src, p.String(), tt.want)
+		}
+	}
+}
+
+func TestNullPURLValue(t *testing.T) {
+	v, err := NullPURL{}.Value()
+	if err != nil || v != nil {
+		t.Errorf("invalid NullPURL Value() = %v, %v; want nil", v, err)
+	}
+
+	p, _ := Parse("pkg:pypi/requests@2.31.0")
+	v, err = NullPURL{PURL: *p, Valid: true}.Value()
+	if err != nil || v != "pkg:pypi/requests@2.31.0" {
+		t.Errorf("NullPURL Value() = %v, %v; want %q", v, err, "pkg:pypi/requests@2.31.0")
+	}
+
+	var n NullPURL
+	if err := n.Scan("bogus"); err == nil || n.Valid {
+		t.Errorf("Scan(bogus) = %v, Valid %v; want error and invalid", err, n.Valid)
+	}
+}
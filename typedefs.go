@@ -0,0 +1,160 @@
+package purl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// typeDefinition is an upstream purl-spec type definition file, such as
+// types/npm-definition.json. Fields this package has no use for are ignored
+// so newer revisions of the schema still load.
+type typeDefinition struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Repository  *struct {
+		UseRepository        bool   `json:"use_repository"`
+		DefaultRepositoryURL string `json:"default_repository_url"`
+	} `json:"repository"`
+	Namespace  *ComponentDefinition `json:"namespace_definition"`
+	Name       *ComponentDefinition `json:"name_definition"`
+	Version    *ComponentDefinition `json:"version_definition"`
+	Qualifiers []struct {
+		Key         string `json:"key"`
+		Requirement string `json:"requirement"`
+		Description string `json:"description"`
+	} `json:"qualifiers_definition"`
+	Examples []string `json:"examples"`
+}
+
+// specFields holds the TypeConfig fields owned by the upstream definitions.
+type specFields struct {
+	Description          string                `json:"description,omitempty"`
+	NamespaceRequirement string                `json:"namespace_requirement,omitempty"`
+	Examples             []string              `json:"examples,omitempty"`
+	Qualifiers           []QualifierDefinition `json:"qualifiers,omitempty"`
+	NamespaceDefinition  *ComponentDefinition  `json:"namespace_definition,omitempty"`
+	NameDefinition       *ComponentDefinition  `json:"name_definition,omitempty"`
+	VersionDefinition    *ComponentDefinition  `json:"version_definition,omitempty"`
+}
+
+// LoadTypeDefinitions loads upstream purl-spec type definitions into the
+// default registry. See Registry.LoadTypeDefinitions.
+func LoadTypeDefinitions(fsys fs.FS) error {
+	return defaultRegistry().LoadTypeDefinitions(fsys)
+}
+
+// LoadTypeDefinitions reads the upstream purl-spec type definition files,
+// named "<type>-definition.json", from the root of fsys and applies them to
+// the registry. Fields the spec defines (description, default registry,
+// namespace requirement, component definitions, qualifiers and examples)
+// replace the registry's values; extensions the spec has no equivalent for,
+// such as registry_config, version_scheme and qualifier value patterns, are
+// kept. Types not already in the registry are added. Nothing is changed if
+// any file is invalid.
+//
+// To load the definitions from a checkout of purl-spec:
+//
+//	err := reg.LoadTypeDefinitions(os.DirFS("purl-spec/types"))
+func (r *Registry) LoadTypeDefinitions(fsys fs.FS) error {
+	paths, err := fs.Glob(fsys, "*-definition.json")
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("%w: no *-definition.json files found", ErrInvalidTypes)
+	}
+
+	defs := make([]*typeDefinition, 0, len(paths))
+	for _, p := range paths {
+		def, err := readTypeDefinition(fsys, p)
+		if err != nil {
+			return err
+		}
+		defs = append(defs, def)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := r.cat.clone()
+	for _, def := range defs {
+		var existing []QualifierDefinition
+		if e, ok := next.types[def.Type]; ok {
+			existing = e.cfg.Qualifiers
+		}
+		fields, err := def.fields(existing)
+		if err != nil {
+			return err
+		}
+		if err := next.setFields(def.Type, fields, SourceSpec); err != nil {
+			return err
+		}
+	}
+	if err := next.checkAliases(); err != nil {
+		return err
+	}
+	r.setCatalog(next)
+	return nil
+}
+
+// readTypeDefinition decodes one definition file and checks that its type
+// matches the file name.
+func readTypeDefinition(fsys fs.FS, name string) (*typeDefinition, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	var def typeDefinition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTypes, name, err)
+	}
+	if want := strings.TrimSuffix(path.Base(name), "-definition.json"); def.Type != want {
+		return nil, fmt.Errorf("%w: %s: type %q does not match file name", ErrInvalidTypes, name, def.Type)
+	}
+	return &def, nil
+}
+
+// fields converts the definition to TypeConfig fields in JSON form. Value
+// patterns from existing qualifier definitions are carried over, since the
+// spec does not record them.
+func (d *typeDefinition) fields(existing []QualifierDefinition) (map[string]any, error) {
+	patterns := make(map[string]string, len(existing))
+	for _, q := range existing {
+		patterns[q.Key] = q.Pattern
+	}
+
+	sf := specFields{
+		Description:         d.Description,
+		Examples:            d.Examples,
+		NamespaceDefinition: d.Namespace,
+		NameDefinition:      d.Name,
+		VersionDefinition:   d.Version,
+	}
+	if d.Namespace != nil {
+		sf.NamespaceRequirement = d.Namespace.Requirement
+	}
+	for _, q := range d.Qualifiers {
+		sf.Qualifiers = append(sf.Qualifiers, QualifierDefinition{
+			Key:         q.Key,
+			Description: q.Description,
+			Required:    q.Requirement == "required",
+			Pattern:     patterns[q.Key],
+		})
+	}
+
+	fields, err := toRaw(sf)
+	if err != nil {
+		return nil, err
+	}
+	if d.Repository != nil {
+		if d.Repository.UseRepository && d.Repository.DefaultRepositoryURL != "" {
+			fields["default_registry"] = d.Repository.DefaultRepositoryURL
+		} else {
+			fields["default_registry"] = nil
+		}
+	}
+	return fields, nil
+}
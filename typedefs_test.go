@@ -0,0 +1,152 @@
+package purl
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+const npmDefinition = `{
+  "$schema": "https://packageurl.org/schemas/purl-type-definition.schema-1.0.json",
+  "$id": "https://packageurl.org/types/npm-definition.json",
+  "type": "npm",
+  "type_name": "Node NPM packages",
+  "description": "PURL type for npm packages.",
+  "repository": {
+    "use_repository": true,
+    "default_repository_url": "https://registry.npmjs.org/"
+  },
+  "namespace_definition": {
+    "requirement": "optional",
+    "native_name": "scope",
+    "case_sensitive": false,
+    "note": "The npm scope, starting with '@'."
+  },
+  "name_definition": {
+    "native_name": "name",
+    "case_sensitive": false
+  },
+  "version_definition": {
+    "native_name": "version"
+  },
+  "qualifiers_definition": [],
+  "examples": ["pkg:npm/foobar@12.3.1", "pkg:npm/%40angular/animation@12.3.1"]
+}`
+
+const juliaDefinition = `{
+  "type": "julia",
+  "description": "Julia packages",
+  "repository": {
+    "use_repository": true,
+    "default_repository_url": "https://github.com/JuliaRegistries/General"
+  },
+  "namespace_definition": {"requirement": "prohibited"},
+  "name_definition": {"case_sensitive": true},
+  "qualifiers_definition": [
+    {"key": "uuid", "requirement": "required", "description": "Package UUID."}
+  ],
+  "examples": ["pkg:julia/AWS@1.92.0?uuid=fbe9abb3-538b-5e4e-ba9e-bc94f4f92ebc"]
+}`
+
+func TestLoadTypeDefinitions(t *testing.T) {
+	r := NewRegistry()
+	before := *r.TypeInfo("npm")
+
+	fsys := fstest.MapFS{
+		"npm-definition.json":   {Data: []byte(npmDefinition)},
+		"julia-definition.json": {Data: []byte(juliaDefinition)},
+		"README.md":             {Data: []byte("not a definition")},
+	}
+	if err := r.LoadTypeDefinitions(fsys); err != nil {
+		t.Fatalf("LoadTypeDefinitions() error: %v", err)
+	}
+
+	npm := r.TypeInfo("npm")
+	if npm.Description != "PURL type for npm packages." {
+		t.Errorf("Description = %q", npm.Description)
+	}
+	if got := r.DefaultRegistry("npm"); got != "https://registry.npmjs.org/" {
+		t.Errorf("DefaultRegistry() = %q", got)
+	}
+	if npm.NamespaceRequirement != "optional" {
+		t.Errorf("NamespaceRequirement = %q, want optional", npm.NamespaceRequirement)
+	}
+	if npm.NamespaceDefinition == nil || npm.NamespaceDefinition.NativeName != "scope" {
+		t.Errorf("NamespaceDefinition = %+v", npm.NamespaceDefinition)
+	}
+	if cs := npm.NameDefinition.CaseSensitive; cs == nil || *cs {
+		t.Errorf("NameDefinition.CaseSensitive = %v, want false", cs)
+	}
+	if len(npm.Examples) != 2 {
+		t.Errorf("Examples = %v", npm.Examples)
+	}
+
+	// Extensions the spec doesn't define are kept
+	if npm.RegistryConfig == nil || npm.RegistryConfig.URITemplate != before.RegistryConfig.URITemplate {
+		t.Errorf("RegistryConfig = %+v, want embedded registry_config kept", npm.RegistryConfig)
+	}
+	if npm.VersionScheme != before.VersionScheme {
+		t.Errorf("VersionScheme = %q, want %q", npm.VersionScheme, before.VersionScheme)
+	}
+
+	sources := r.TypeFieldSources("npm")
+	if sources["description"] != SourceSpec || sources["registry_config.uri_template"] != SourceEmbedded {
+		t.Errorf("sources = %v", sources)
+	}
+
+	julia := r.TypeInfo("julia")
+	if julia == nil || !julia.NamespaceProhibited() {
+		t.Fatalf("TypeInfo(julia) = %+v", julia)
+	}
+	if len(julia.Qualifiers) != 1 || !julia.Qualifiers[0].Required {
+		t.Errorf("Qualifiers = %+v, want required uuid", julia.Qualifiers)
+	}
+	if IsKnownType("julia") {
+		t.Error("LoadTypeDefinitions on a Registry changed the default registry")
+	}
+}
+
+func TestLoadTypeDefinitionsKeepsQualifierPatterns(t *testing.T) {
+	r := NewRegistry()
+	fsys := fstest.MapFS{"rpm-definition.json": {Data: []byte(`{
+		"type": "rpm",
+		"namespace_definition": {"requirement": "required"},
+		"qualifiers_definition": [
+			{"key": "arch", "description": "Architecture."},
+			{"key": "epoch", "description": "Epoch."}
+		]
+	}`)}}
+	if err := r.LoadTypeDefinitions(fsys); err != nil {
+		t.Fatalf("LoadTypeDefinitions() error: %v", err)
+	}
+	for _, q := range r.TypeInfo("rpm").Qualifiers {
+		if q.Key == "epoch" && q.Pattern == "" {
+			t.Error("epoch pattern was dropped")
+		}
+	}
+}
+
+func TestLoadTypeDefinitionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"no files", fstest.MapFS{"README.md": {Data: []byte("")}}},
+		{"bad json", fstest.MapFS{"npm-definition.json": {Data: []byte(`{"type":`)}}},
+		{"type mismatch", fstest.MapFS{"npm-definition.json": {Data: []byte(`{"type": "pypi"}`)}}},
+		{"bad requirement", fstest.MapFS{"npm-definition.json": {Data: []byte(`{"type": "npm", "namespace_definition": {"requirement": "sometimes"}}`)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			want := r.TypeInfo("npm").Description
+			err := r.LoadTypeDefinitions(tt.fsys)
+			if !errors.Is(err, ErrInvalidTypes) {
+				t.Errorf("LoadTypeDefinitions() error = %v, want ErrInvalidTypes", err)
+			}
+			if got := r.TypeInfo("npm").Description; got != want {
+				t.Error("failed load modified the registry")
+			}
+		})
+	}
+}
@@ -2,21 +2,64 @@ package purl
 
 import (
 	_ "embed"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
 	"sort"
 	"sync"
 )
 
+//go:generate go run ./internal/gentypes -i types.json -o typeconsts.go
+
 //go:embed types.json
 var typesJSON []byte
 
 // TypeConfig contains configuration for a PURL type.
 type TypeConfig struct {
-	Description          string          `json:"description"`
-	DefaultRegistry      *string         `json:"default_registry"`
-	NamespaceRequirement string          `json:"namespace_requirement"`
-	Examples             []string        `json:"examples"`
-	RegistryConfig       *RegistryConfig `json:"registry_config"`
+	Description          string                `json:"description"`
+	DefaultRegistry      *string               `json:"default_registry"`
+	EcosystemsRegistry   string                `json:"ecosystems_registry,omitempty"`
+	NamespaceRequirement string                `json:"namespace_requirement"`
+	VersionScheme        string                `json:"version_scheme,omitempty"`
+	Aliases              []string              `json:"aliases,omitempty"`
+	Deprecated           *Deprecation          `json:"deprecated,omitempty"`
+	Examples             []string              `json:"examples"`
+	Qualifiers           []QualifierDefinition `json:"qualifiers,omitempty"`
+	RegistryConfig       *RegistryConfig       `json:"registry_config"`
+
+	// Component rules from the upstream purl-spec type definition, when
+	// loaded with LoadTypeDefinitions.
+	NamespaceDefinition *ComponentDefinition `json:"namespace_definition,omitempty"`
+	NameDefinition      *ComponentDefinition `json:"name_definition,omitempty"`
+	VersionDefinition   *ComponentDefinition `json:"version_definition,omitempty"`
+}
+
+// ComponentDefinition describes how a type uses one PURL component, as
+// recorded in the upstream purl-spec type definitions.
+type ComponentDefinition struct {
+	Requirement        string   `json:"requirement,omitempty"`
+	NativeName         string   `json:"native_name,omitempty"`
+	CaseSensitive      *bool    `json:"case_sensitive,omitempty"`
+	NormalizationRules []string `json:"normalization_rules,omitempty"`
+	Note               string   `json:"note,omitempty"`
+}
+
+// Deprecation marks a PURL type as deprecated. Unlike an alias, a deprecated
+// type is still valid and is not rewritten, because its PURLs may not map
+// one-to-one onto the replacement.
+type Deprecation struct {
+	ReplacedBy string `json:"replaced_by,omitempty"` // preferred type, if any
+	Message    string `json:"message,omitempty"`
+}
+
+// QualifierDefinition describes a qualifier key defined for a PURL type.
+// The standard qualifiers such as repository_url and checksum are allowed on
+// every type and need not be listed.
+type QualifierDefinition struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	Required    bool   `json:"required,omitempty"`
+	Pattern     string `json:"pattern,omitempty"` // regular expression allowed values must match
 }
 
 // RegistryConfig contains URL templates and patterns for registry URLs.
@@ -27,6 +70,12 @@ type RegistryConfig struct {
 	URITemplateNoNamespace     string             `json:"uri_template_no_namespace"`
 	URITemplateWithVersion     string             `json:"uri_template_with_version"`
 	URITemplateWithVersionNoNS string             `json:"uri_template_with_version_no_namespace"`
+	APITemplate                string             `json:"api_template,omitempty"`
+	APITemplateNoNamespace     string             `json:"api_template_no_namespace,omitempty"`
+	DownloadTemplate           string             `json:"download_template,omitempty"`
+	DownloadTemplateNoNS       string             `json:"download_template_no_namespace,omitempty"`
+	DownloadQualifierDefaults  map[string]string  `json:"download_qualifier_defaults,omitempty"`
+	DownloadRegex              string             `json:"download_regex,omitempty"`
 	Components                 RegistryComponents `json:"components"`
 }
 
@@ -62,72 +111,212 @@ type typesData struct {
 	Types       map[string]TypeConfig `json:"types"`
 }
 
-var (
-	loadOnce   sync.Once
-	loadedData *typesData
+// Registry holds a set of PURL type configurations and answers the
+// type-dependent questions the package functions do: TypeInfo, RegistryURL,
+// ParseRegistryURL, IsDefaultRegistry and so on. Each Registry is
+// independent, so services with different private registries or test
+// fixtures can coexist in one process. The package-level functions use a
+// default Registry built from the embedded types.json.
+//
+// The zero Registry is empty and ready to use. A Registry is safe for
+// concurrent use and must not be copied after first use.
+type Registry struct {
+	mu         sync.RWMutex
+	cat        catalog
 	loadErr    error
+	regexCache sync.Map
+	hosts      *hostIndex          // ParseRegistryURL dispatch, rebuilt with cat
+	downloads  []downloadCandidate // ParseDownloadURL candidates, rebuilt with cat
+}
+
+// NewRegistry returns a Registry populated from the embedded types.json.
+// If the embedded data fails to load, the registry is empty and
+// CatalogError reports why.
+func NewRegistry() *Registry {
+	c, err := embeddedCatalog()
+	if err != nil {
+		return &Registry{loadErr: err}
+	}
+	r := &Registry{}
+	r.setCatalog(c.clone())
+	return r
+}
+
+var (
+	defaultOnce sync.Once
+	defaultReg  *Registry
 )
 
-func loadTypes() (*typesData, error) {
-	loadOnce.Do(func() {
-		loadedData = &typesData{}
-		loadErr = json.Unmarshal(typesJSON, loadedData)
-	})
-	return loadedData, loadErr
+// defaultRegistry returns the Registry used by the package-level functions.
+func defaultRegistry() *Registry {
+	defaultOnce.Do(func() { defaultReg = NewRegistry() })
+	return defaultReg
 }
 
-// TypeInfo returns configuration for a PURL type, or nil if unknown.
-func TypeInfo(purlType string) *TypeConfig {
-	data, err := loadTypes()
+// ErrTypeConflict is returned when RegisterType would redefine an existing
+// type without AllowOverride.
+var ErrTypeConflict = errors.New("purl type already defined")
+
+// RegisterOption configures RegisterType.
+type RegisterOption func(*registerOptions)
+
+type registerOptions struct {
+	override bool
+}
+
+// AllowOverride lets RegisterType replace an existing definition, including
+// one from the embedded types.json.
+func AllowOverride() RegisterOption {
+	return func(o *registerOptions) { o.override = true }
+}
+
+// RegisterType adds a PURL type to the registry so that TypeInfo,
+// IsKnownType, KnownTypes, RegistryURL and ParseRegistryURL recognize it.
+// Registering a type that already exists with a different configuration
+// returns ErrTypeConflict unless AllowOverride is passed; registering an
+// identical configuration again is a no-op. An alias that names another type
+// or is another type's alias returns ErrInvalidTypes.
+func (r *Registry) RegisterType(name string, cfg TypeConfig, opts ...RegisterOption) error {
+	var o registerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := validateTypeConfig(name, &cfg); err != nil {
+		return err
+	}
+	raw, err := toRaw(cfg)
 	if err != nil {
-		return nil
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.cat.types[name]; ok && !o.override && !reflect.DeepEqual(existing.cfg, cfg) {
+		return fmt.Errorf("%w: %q", ErrTypeConflict, name)
+	}
+	if r.cat.types == nil {
+		r.cat.types = make(map[string]*catalogEntry)
+	}
+	prev, had := r.cat.types[name]
+	r.cat.types[name] = newCatalogEntry(raw, cfg, SourceRegistered)
+	if err := r.cat.checkAliases(); err != nil {
+		if had {
+			r.cat.types[name] = prev
+		} else {
+			delete(r.cat.types, name)
+		}
+		return err
+	}
+	r.rebuildIndexes()
+	return nil
+}
+
+// lookupType returns the configuration for a type.
+func (r *Registry) lookupType(purlType string) (TypeConfig, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.cat.types[purlType]
+	if !ok {
+		return TypeConfig{}, false
 	}
-	cfg, ok := data.Types[purlType]
+	return e.cfg, true
+}
+
+// TypeInfo returns configuration for a PURL type, or nil if unknown.
+func (r *Registry) TypeInfo(purlType string) *TypeConfig {
+	cfg, ok := r.lookupType(purlType)
 	if !ok {
 		return nil
 	}
 	return &cfg
 }
 
-// KnownTypes returns a sorted list of all known PURL types.
-func KnownTypes() []string {
-	data, err := loadTypes()
-	if err != nil {
+// KnownTypes returns a sorted list of all types in the registry.
+func (r *Registry) KnownTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.cat.types) == 0 {
 		return nil
 	}
-	types := make([]string, 0, len(data.Types))
-	for t := range data.Types {
+	types := make([]string, 0, len(r.cat.types))
+	for t := range r.cat.types {
 		types = append(types, t)
 	}
 	sort.Strings(types)
 	return types
 }
 
-// IsKnownType returns true if the PURL type is defined in types.json.
-func IsKnownType(purlType string) bool {
-	data, err := loadTypes()
-	if err != nil {
-		return false
-	}
-	_, ok := data.Types[purlType]
+// IsKnownType returns true if the PURL type is in the registry.
+func (r *Registry) IsKnownType(purlType string) bool {
+	_, ok := r.lookupType(purlType)
 	return ok
 }
 
 // DefaultRegistry returns the default registry URL for a PURL type.
 // Returns empty string if the type has no default registry.
-func DefaultRegistry(purlType string) string {
-	cfg := TypeInfo(purlType)
+func (r *Registry) DefaultRegistry(purlType string) string {
+	cfg := r.TypeInfo(purlType)
 	if cfg == nil || cfg.DefaultRegistry == nil {
 		return ""
 	}
 	return *cfg.DefaultRegistry
 }
 
+// VersionSchemeFor returns the vers scheme used to interpret versions and
+// version constraints for a PURL type, such as "alpine" for apk. Types
+// without a version_scheme in the registry map to their own name.
+func (r *Registry) VersionSchemeFor(purlType string) string {
+	if cfg, ok := r.lookupType(purlType); ok && cfg.VersionScheme != "" {
+		return cfg.VersionScheme
+	}
+	return purlType
+}
+
+// TypesVersion returns the version of the loaded types data.
+func (r *Registry) TypesVersion() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cat.version
+}
+
+// RegisterType adds a PURL type to the default registry. See
+// Registry.RegisterType.
+func RegisterType(name string, cfg TypeConfig, opts ...RegisterOption) error {
+	return defaultRegistry().RegisterType(name, cfg, opts...)
+}
+
+// TypeInfo returns configuration for a PURL type, or nil if unknown.
+func TypeInfo(purlType string) *TypeConfig {
+	return defaultRegistry().TypeInfo(purlType)
+}
+
+// KnownTypes returns a sorted list of all known PURL types, including
+// registered and loaded ones.
+func KnownTypes() []string {
+	return defaultRegistry().KnownTypes()
+}
+
+// IsKnownType returns true if the PURL type is known.
+func IsKnownType(purlType string) bool {
+	return defaultRegistry().IsKnownType(purlType)
+}
+
+// DefaultRegistry returns the default registry URL for a PURL type.
+// Returns empty string if the type has no default registry.
+func DefaultRegistry(purlType string) string {
+	return defaultRegistry().DefaultRegistry(purlType)
+}
+
+// VersionSchemeFor returns the vers scheme for a PURL type.
+// See Registry.VersionSchemeFor.
+func VersionSchemeFor(purlType string) string {
+	return defaultRegistry().VersionSchemeFor(purlType)
+}
+
 // TypesVersion returns the version of the types.json data.
 func TypesVersion() string {
-	data, err := loadTypes()
-	if err != nil {
-		return ""
-	}
-	return data.Version
+	return defaultRegistry().TypesVersion()
 }
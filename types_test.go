@@ -1,6 +1,9 @@
 package purl
 
 import (
+	"errors"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -152,3 +155,251 @@ func TestTypesVersion(t *testing.T) {
 		t.Error("TypesVersion() returned empty string")
 	}
 }
+
+// restoreCatalog snapshots the default registry and restores it when the test
+// ends, so registrations and loads don't leak between tests.
+func restoreCatalog(t *testing.T) {
+	t.Helper()
+	r := defaultRegistry()
+	r.mu.RLock()
+	saved := r.cat.clone()
+	r.mu.RUnlock()
+	t.Cleanup(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.setCatalog(saved)
+	})
+}
+
+func TestRegisterType(t *testing.T) {
+	restoreCatalog(t)
+
+	registry := "https://artifacts.acme.example"
+	cfg := TypeConfig{
+		Description:          "Acme internal artifacts",
+		DefaultRegistry:      &registry,
+		NamespaceRequirement: "required",
+		RegistryConfig: &RegistryConfig{
+			BaseURL:      "https://artifacts.acme.example/browse",
+			ReverseRegex: `^https://artifacts\.acme\.example/browse/([^/?#]+)/([^/?#]+)`,
+			URITemplate:  "https://artifacts.acme.example/browse/{namespace}/{name}",
+			Components:   RegistryComponents{Namespace: true, NamespaceRequired: true},
+		},
+	}
+	if err := RegisterType("acme-artifact", cfg); err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+
+	if !IsKnownType("acme-artifact") {
+		t.Error("IsKnownType() = false after RegisterType")
+	}
+	if got := TypeInfo("acme-artifact"); got == nil || got.Description != cfg.Description {
+		t.Errorf("TypeInfo() = %v, want registered config", got)
+	}
+	found := false
+	for _, typ := range KnownTypes() {
+		if typ == "acme-artifact" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("KnownTypes() missing registered type")
+	}
+	if got := DefaultRegistry("acme-artifact"); got != registry {
+		t.Errorf("DefaultRegistry() = %q, want %q", got, registry)
+	}
+
+	p := New("acme-artifact", "team", "widget", "1.0.0", nil)
+	url, err := p.RegistryURL()
+	if err != nil || url != "https://artifacts.acme.example/browse/team/widget" {
+		t.Errorf("RegistryURL() = %q, %v", url, err)
+	}
+	parsed, err := ParseRegistryURL("https://artifacts.acme.example/browse/team/widget")
+	if err != nil || parsed.String() != "pkg:acme-artifact/team/widget" {
+		t.Errorf("ParseRegistryURL() = %v, %v", parsed, err)
+	}
+
+	// Identical re-registration is allowed
+	if err := RegisterType("acme-artifact", cfg); err != nil {
+		t.Errorf("RegisterType() identical error: %v", err)
+	}
+
+	// Conflicting redefinition needs AllowOverride
+	changed := cfg
+	changed.Description = "changed"
+	if err := RegisterType("acme-artifact", changed); !errors.Is(err, ErrTypeConflict) {
+		t.Errorf("RegisterType() conflict error = %v, want ErrTypeConflict", err)
+	}
+	if err := RegisterType("acme-artifact", changed, AllowOverride()); err != nil {
+		t.Errorf("RegisterType() override error: %v", err)
+	}
+	if got := TypeInfo("acme-artifact").Description; got != "changed" {
+		t.Errorf("Description after override = %q, want %q", got, "changed")
+	}
+}
+
+func TestRegisterTypeOverrideEmbedded(t *testing.T) {
+	restoreCatalog(t)
+
+	if err := RegisterType("npm", TypeConfig{Description: "mine"}); !errors.Is(err, ErrTypeConflict) {
+		t.Errorf("RegisterType(npm) error = %v, want ErrTypeConflict", err)
+	}
+	if err := RegisterType("npm", TypeConfig{Description: "mine"}, AllowOverride()); err != nil {
+		t.Fatalf("RegisterType(npm, AllowOverride) error: %v", err)
+	}
+	if got := TypeInfo("npm").Description; got != "mine" {
+		t.Errorf("Description = %q, want %q", got, "mine")
+	}
+}
+
+func TestRegisterTypeInvalid(t *testing.T) {
+	if err := RegisterType("1bad", TypeConfig{}); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("RegisterType(1bad) error = %v, want ErrInvalidType", err)
+	}
+	bad := TypeConfig{RegistryConfig: &RegistryConfig{ReverseRegex: "("}}
+	if err := RegisterType("bad-regex", bad); err == nil {
+		t.Error("RegisterType(bad regex) error = nil, want error")
+	}
+
+	r := NewRegistry()
+	for _, alias := range []string{"npm", "rubygems"} {
+		if err := r.RegisterType("acme", TypeConfig{Aliases: []string{alias}}); !errors.Is(err, ErrInvalidTypes) {
+			t.Errorf("RegisterType(alias %s) error = %v, want ErrInvalidTypes", alias, err)
+		}
+	}
+	if r.IsKnownType("acme") {
+		t.Error("rejected RegisterType left the type registered")
+	}
+}
+
+func TestRegisterTypeConcurrent(t *testing.T) {
+	names := []string{"conc-a", "conc-b", "conc-c", "conc-d"}
+	restoreCatalog(t)
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = RegisterType(name, TypeConfig{Description: name})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = KnownTypes()
+			_ = TypeInfo(name)
+		}()
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		if !IsKnownType(name) {
+			t.Errorf("IsKnownType(%q) = false", name)
+		}
+	}
+}
+
+func TestRegistryIsolation(t *testing.T) {
+	t.Parallel()
+
+	base := "https://npm.acme.example"
+	r := NewRegistry()
+	doc := `{"types": {"npm": {"default_registry": "` + base + `", "registry_config": {"uri_template": "` + base + `/-/web/detail/{namespace}/{name}", "uri_template_no_namespace": "` + base + `/-/web/detail/{name}", "reverse_regex": "^https://npm\\.acme\\.example/-/web/detail/(?:(@[^/]+)/)?([^/]+)"}}}}`
+	if err := r.MergeTypes(strings.NewReader(doc)); err != nil {
+		t.Fatalf("MergeTypes() error: %v", err)
+	}
+	if err := r.RegisterType("acme", TypeConfig{Description: "Acme"}); err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+
+	p := New("npm", "", "lodash", "", nil)
+	got, err := r.RegistryURL(p)
+	if err != nil || got != base+"/-/web/detail/lodash" {
+		t.Errorf("Registry.RegistryURL() = %q, %v", got, err)
+	}
+	if got, _ := p.RegistryURL(); got != "https://www.npmjs.com/package/lodash" {
+		t.Errorf("PURL.RegistryURL() = %q, want default registry unchanged", got)
+	}
+
+	parsed, err := r.ParseRegistryURL(base + "/-/web/detail/lodash")
+	if err != nil || parsed.String() != "pkg:npm/lodash" {
+		t.Errorf("Registry.ParseRegistryURL() = %v, %v", parsed, err)
+	}
+	if _, err := ParseRegistryURL(base + "/-/web/detail/lodash"); err == nil {
+		t.Error("package ParseRegistryURL() matched another registry's URL")
+	}
+
+	if !r.IsDefaultRegistry("npm", base) {
+		t.Error("Registry.IsDefaultRegistry() = false for its own default")
+	}
+	if IsDefaultRegistry("npm", base) {
+		t.Error("package IsDefaultRegistry() = true for another registry's default")
+	}
+	if !r.IsKnownType("acme") || IsKnownType("acme") {
+		t.Error("RegisterType on a Registry leaked into the default registry")
+	}
+	if r.TypesVersion() != TypesVersion() {
+		t.Errorf("TypesVersion() = %q, want embedded %q", r.TypesVersion(), TypesVersion())
+	}
+}
+
+func TestRegistryZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var r Registry
+	if got := r.KnownTypes(); got != nil {
+		t.Errorf("KnownTypes() = %v, want nil", got)
+	}
+	if r.IsKnownType("npm") {
+		t.Error("IsKnownType(npm) = true on empty registry")
+	}
+	if _, err := r.RegistryURL(New("npm", "", "lodash", "", nil)); !errors.Is(err, ErrNoRegistryConfig) {
+		t.Errorf("RegistryURL() error = %v, want ErrNoRegistryConfig", err)
+	}
+	if err := r.RegisterType("acme", TypeConfig{Description: "Acme"}); err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+	if got := r.KnownTypes(); len(got) != 1 || got[0] != "acme" {
+		t.Errorf("KnownTypes() = %v, want [acme]", got)
+	}
+}
+
+func TestVersionSchemeFor(t *testing.T) {
+	tests := []struct {
+		purlType string
+		want     string
+	}{
+		{"gem", "gem"},
+		{"composer", "composer"},
+		{"golang", "golang"},
+		{"deb", "deb"},
+		{"apk", "alpine"},
+		{"alpm", "arch"},
+		{"clojars", "maven"},
+		{"github", "github"},
+		{"unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.purlType, func(t *testing.T) {
+			if got := VersionSchemeFor(tt.purlType); got != tt.want {
+				t.Errorf("VersionSchemeFor(%q) = %q, want %q", tt.purlType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeConstants(t *testing.T) {
+	for _, typ := range []Type{TypeNPM, TypeMaven, TypeGolang, TypeALPM, TypePyPI} {
+		if typ.Config() == nil {
+			t.Errorf("%s.Config() = nil", typ)
+		}
+	}
+	if got := TypeNPM.Config(); got.Description != TypeInfo("npm").Description {
+		t.Errorf("TypeNPM.Config() = %+v, want TypeInfo(npm)", got)
+	}
+	if Type("not-a-type").Config() != nil {
+		t.Error("Config() for unknown type != nil")
+	}
+	if TypeNPM.String() != "npm" {
+		t.Errorf("TypeNPM.String() = %q", TypeNPM.String())
+	}
+}
@@ -0,0 +1,182 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// Names of the PURL components, used to report which part of a PURL is invalid.
+const (
+	ComponentScheme     = "scheme"
+	ComponentType       = "type"
+	ComponentNamespace  = "namespace"
+	ComponentName       = "name"
+	ComponentVersion    = "version"
+	ComponentQualifiers = "qualifiers"
+	ComponentSubpath    = "subpath"
+)
+
+// ErrUnknownType is returned when a PURL's type is not defined in types.json.
+var ErrUnknownType = errors.New("unknown purl type")
+
+// ErrInvalidType is returned when a PURL's type contains characters the spec does not allow.
+var ErrInvalidType = errors.New("invalid purl type")
+
+// ErrEmptyName is returned when a PURL has no name.
+var ErrEmptyName = errors.New("purl name is empty")
+
+// ErrNamespaceRequired is returned when a type requires a namespace and none is set.
+var ErrNamespaceRequired = errors.New("namespace is required for this type")
+
+// ErrNamespaceProhibited is returned when a type prohibits namespaces and one is set.
+var ErrNamespaceProhibited = errors.New("namespace is not allowed for this type")
+
+// ErrIllegalCharacter is returned when a component contains a character the type does not allow.
+var ErrIllegalCharacter = errors.New("illegal character")
+
+// ValidationError describes why a PURL failed validation.
+// It unwraps to one of the Err* sentinel errors so callers can use errors.Is.
+type ValidationError struct {
+	Type      string // PURL type being validated
+	Component string // one of the Component* constants
+	Err       error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s purl: %s: %v", e.Type, e.Component, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// illegalNameChars lists characters that a type's registry never accepts in
+// namespaces and names, beyond the checks applied to every type. Characters
+// a registry merely stopped accepting for new packages are left out, since
+// older names that use them still appear in lockfiles: npm once allowed
+// ~'!()* in names.
+var illegalNameChars = map[Type]string{
+	TypeMaven: ":",
+}
+
+// whitespaceAllowed lists types whose namespaces and names may contain
+// whitespace. Every other type rejects it.
+var whitespaceAllowed = map[Type]bool{
+	TypeGeneric: true,
+	TypeSWID:    true,
+}
+
+// Validate checks the PURL against the rules recorded for its type in
+// types.json. See Registry.Validate.
+func (p *PURL) Validate() error {
+	return defaultRegistry().Validate(p)
+}
+
+// Validate checks the PURL against the rules recorded for its type in this
+// registry: the type must be known, the name non-empty, the namespace
+// present or absent as the type requires, and components free of characters
+// the type does not allow. It returns a *ValidationError on failure.
+func (r *Registry) Validate(p *PURL) error {
+	if !packageurl.TypePattern.MatchString(p.Type) {
+		return &ValidationError{Type: p.Type, Component: ComponentType, Err: ErrInvalidType}
+	}
+
+	cfg := r.TypeInfo(p.Type)
+	if cfg == nil {
+		return &ValidationError{Type: p.Type, Component: ComponentType, Err: ErrUnknownType}
+	}
+
+	if p.Name == "" {
+		return &ValidationError{Type: p.Type, Component: ComponentName, Err: ErrEmptyName}
+	}
+
+	switch {
+	case cfg.NamespaceRequired() && p.Namespace == "":
+		return &ValidationError{Type: p.Type, Component: ComponentNamespace, Err: ErrNamespaceRequired}
+	case cfg.NamespaceProhibited() && p.Namespace != "":
+		return &ValidationError{Type: p.Type, Component: ComponentNamespace, Err: ErrNamespaceProhibited}
+	}
+
+	if err := checkNamespace(p.Type, p.Namespace); err != nil {
+		return &ValidationError{Type: p.Type, Component: ComponentNamespace, Err: err}
+	}
+	if err := checkName(p.Type, p.Name); err != nil {
+		return &ValidationError{Type: p.Type, Component: ComponentName, Err: err}
+	}
+	if err := checkVersion(p.Version); err != nil {
+		return &ValidationError{Type: p.Type, Component: ComponentVersion, Err: err}
+	}
+
+	return nil
+}
+
+// ValidateString parses s and validates the result against the default
+// registry. See Registry.ValidateString.
+func ValidateString(s string) error {
+	return defaultRegistry().ValidateString(s)
+}
+
+// ValidateString parses s with this registry and validates the result.
+// Parse errors are returned unchanged.
+func (r *Registry) ValidateString(s string) error {
+	p, err := r.Parse(s)
+	if err != nil {
+		return err
+	}
+	return r.Validate(p)
+}
+
+// checkNamespace applies the per-type namespace character rules.
+func checkNamespace(purlType, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if Type(purlType) == TypeNPM && !strings.HasPrefix(namespace, "@") {
+		return fmt.Errorf("%w: npm scope must start with '@'", ErrIllegalCharacter)
+	}
+	for _, segment := range strings.Split(namespace, "/") {
+		if segment == "" {
+			return fmt.Errorf("%w: empty namespace segment", ErrIllegalCharacter)
+		}
+		if err := checkChars(purlType, segment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkName applies the per-type name character rules.
+func checkName(purlType, name string) error {
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("%w %q", ErrIllegalCharacter, '/')
+	}
+	return checkChars(purlType, name)
+}
+
+// checkVersion rejects control characters and whitespace in versions.
+func checkVersion(version string) error {
+	for _, r := range version {
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return fmt.Errorf("%w %q", ErrIllegalCharacter, r)
+		}
+	}
+	return nil
+}
+
+// checkChars rejects control characters, whitespace where the type does not
+// allow it, and the type's illegalNameChars.
+func checkChars(purlType, s string) error {
+	illegal := illegalNameChars[Type(purlType)]
+	for _, r := range s {
+		if unicode.IsControl(r) ||
+			(unicode.IsSpace(r) && !whitespaceAllowed[Type(purlType)]) ||
+			strings.ContainsRune(illegal, r) {
+			return fmt.Errorf("%w %q", ErrIllegalCharacter, r)
+		}
+	}
+	return nil
+}
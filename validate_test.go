@@ -0,0 +1,109 @@
+package purl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		purl          string
+		wantErr       error
+		wantComponent string
+	}{
+		// Valid
+		{"pkg:npm/lodash@4.17.21", nil, ""},
+		{"pkg:npm/%40babel/core@7.24.0", nil, ""},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.12.0", nil, ""},
+		{"pkg:composer/symfony/console", nil, ""},
+		{"pkg:gem/rails@7.0.4", nil, ""},
+		{"pkg:golang/github.com/gorilla/mux@v1.8.0", nil, ""},
+		{"pkg:generic/my%20tool@1.0", nil, ""},
+		{"pkg:swid/Adobe%20Systems/InDesign@CC", nil, ""},
+		{"pkg:npm/lodash%21", nil, ""},
+		{"pkg:npm/%40scope/it%27s~%28legacy%29%2A@1.0.0", nil, ""},
+
+		// Unknown type
+		{"pkg:terraform/hashicorp/consul/aws", ErrUnknownType, ComponentType},
+
+		// Namespace requirement
+		{"pkg:maven/commons-lang3@3.12.0", ErrNamespaceRequired, ComponentNamespace},
+		{"pkg:composer/console", ErrNamespaceRequired, ComponentNamespace},
+		{"pkg:gem/rails/rails@7.0.4", ErrNamespaceProhibited, ComponentNamespace},
+		{"pkg:cran/tidyverse/dplyr", ErrNamespaceProhibited, ComponentNamespace},
+
+		// Illegal characters
+		{"pkg:npm/babel/core", ErrIllegalCharacter, ComponentNamespace},
+		{"pkg:npm/lod%20ash", ErrIllegalCharacter, ComponentName},
+		{"pkg:cargo/ser%20de", ErrIllegalCharacter, ComponentName},
+		{"pkg:maven/org.apache/commons%3Alang", ErrIllegalCharacter, ComponentName},
+		{"pkg:pypi/requests@2.0%0A", ErrIllegalCharacter, ComponentVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			err = p.Validate()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error is %T, want *ValidationError", err)
+			}
+			if verr.Component != tt.wantComponent {
+				t.Errorf("Component = %q, want %q", verr.Component, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestValidateEmptyName(t *testing.T) {
+	p := &PURL{}
+	p.Type = "npm"
+	if err := p.Validate(); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("Validate() = %v, want ErrEmptyName", err)
+	}
+}
+
+func TestValidateString(t *testing.T) {
+	if err := ValidateString("pkg:cargo/serde@1.0.0"); err != nil {
+		t.Errorf("ValidateString() = %v, want nil", err)
+	}
+	if err := ValidateString("pkg:maven/junit"); !errors.Is(err, ErrNamespaceRequired) {
+		t.Errorf("ValidateString() = %v, want ErrNamespaceRequired", err)
+	}
+	if err := ValidateString("not-a-purl"); err == nil {
+		t.Error("ValidateString() = nil, want parse error")
+	}
+}
+
+func TestRegistryValidate(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterType("acme", TypeConfig{NamespaceRequirement: "required"}); err != nil {
+		t.Fatalf("RegisterType() error: %v", err)
+	}
+
+	if err := r.ValidateString("pkg:acme/tools/anvil@1.0"); err != nil {
+		t.Errorf("Registry.ValidateString() = %v, want nil", err)
+	}
+	if err := r.ValidateString("pkg:acme/anvil@1.0"); !errors.Is(err, ErrNamespaceRequired) {
+		t.Errorf("Registry.ValidateString() = %v, want ErrNamespaceRequired", err)
+	}
+	p := New("acme", "tools", "anvil", "1.0", nil)
+	if err := p.Validate(); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Validate() = %v, want ErrUnknownType from the default registry", err)
+	}
+	if err := r.Validate(p); err != nil {
+		t.Errorf("Registry.Validate() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,37 @@
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/git-pkgs/vers"
+)
+
+// ErrNoVersion is returned when an operation needs a PURL version and the PURL has none.
+var ErrNoVersion = errors.New("purl has no version")
+
+// Satisfies reports whether the PURL's version falls within rangeSpec.
+// rangeSpec is either a vers URI ("vers:npm/>=1.2.0|<2.0.0") or a constraint
+// in the ecosystem's native syntax ("^1.2", "~> 3.0", "[1.0,2.0)"), which is
+// interpreted using the type's scheme from VersionSchemeFor.
+func (p *PURL) Satisfies(rangeSpec string) (bool, error) {
+	if p.Version == "" {
+		return false, ErrNoVersion
+	}
+
+	var (
+		r   *vers.Range
+		err error
+	)
+	if strings.HasPrefix(rangeSpec, "vers:") {
+		r, err = vers.Parse(rangeSpec)
+	} else {
+		r, err = vers.ParseNative(rangeSpec, VersionSchemeFor(p.Type))
+	}
+	if err != nil {
+		return false, fmt.Errorf("parse range %q: %w", rangeSpec, err)
+	}
+
+	return r.Contains(p.Version), nil
+}
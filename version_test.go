@@ -0,0 +1,57 @@
+package purl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		purl      string
+		rangeSpec string
+		want      bool
+	}{
+		// vers URIs
+		{"pkg:npm/lodash@4.17.21", "vers:npm/>=4.0.0|<5.0.0", true},
+		{"pkg:npm/lodash@3.10.1", "vers:npm/>=4.0.0|<5.0.0", false},
+		{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "vers:maven/>=2.0.0|<2.17.1", true},
+
+		// Native constraints, scheme from the PURL type
+		{"pkg:npm/lodash@1.5.0", "^1.2", true},
+		{"pkg:npm/lodash@2.0.0", "^1.2", false},
+		{"pkg:gem/rails@3.2.1", "~> 3.0", true},
+		{"pkg:gem/rails@4.0.0", "~> 3.0", false},
+		{"pkg:maven/junit/junit@1.5", "[1.0,2.0)", true},
+		{"pkg:maven/junit/junit@2.0", "[1.0,2.0)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl+" "+tt.rangeSpec, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			got, err := p.Satisfies(tt.rangeSpec)
+			if err != nil {
+				t.Fatalf("Satisfies(%q) error: %v", tt.rangeSpec, err)
+			}
+			if got != tt.want {
+				t.Errorf("Satisfies(%q) = %v, want %v", tt.rangeSpec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSatisfiesNoVersion(t *testing.T) {
+	p, _ := Parse("pkg:npm/lodash")
+	if _, err := p.Satisfies("^1.0.0"); !errors.Is(err, ErrNoVersion) {
+		t.Errorf("Satisfies() error = %v, want ErrNoVersion", err)
+	}
+}
+
+func TestSatisfiesInvalidRange(t *testing.T) {
+	p, _ := Parse("pkg:npm/lodash@1.0.0")
+	if _, err := p.Satisfies("vers:npm"); err == nil {
+		t.Error("Satisfies() error = nil, want error")
+	}
+}